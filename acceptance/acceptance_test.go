@@ -1,12 +1,14 @@
 package acceptance
 
 import (
+	"fmt"
 	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/recorder"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,17 +16,49 @@ var client *pingdom.Client
 
 var runAcceptance bool
 
-func init() {
-	if os.Getenv("PINGDOM_ACCEPTANCE") == "1" {
+var rec *recorder.Recorder
+
+// TestMain configures the client.  With PINGDOM_ACCEPTANCE=1 the tests run
+// against the real API; if PINGDOM_CASSETTE is also set the session is
+// recorded to that file.  With only PINGDOM_CASSETTE set, the recorded
+// session is replayed without network access.
+func TestMain(m *testing.M) {
+	cassette := os.Getenv("PINGDOM_CASSETTE")
+	config := pingdom.ClientConfig{
+		HTTPClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+
+	var err error
+	switch {
+	case os.Getenv("PINGDOM_ACCEPTANCE") == "1" && cassette != "":
+		rec, err = recorder.New(cassette, recorder.ModeRecord, nil)
+	case cassette != "":
+		rec, err = recorder.New(cassette, recorder.ModeReplay, nil)
+		config.APIToken = "replayed"
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if rec != nil {
+		config.HTTPClient.Transport = rec
+	}
+
+	if os.Getenv("PINGDOM_ACCEPTANCE") == "1" || rec != nil {
 		runAcceptance = true
+		client, _ = pingdom.NewClientWithConfig(config)
+	}
 
-		config := pingdom.ClientConfig{
-			HTTPClient: &http.Client{
-				Timeout: time.Second * 10,
-			},
+	code := m.Run()
+	if rec != nil {
+		if err := rec.Stop(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = 1
 		}
-		client, _ = pingdom.NewClientWithConfig(config)
 	}
+	os.Exit(code)
 }
 
 func TestListChecks(t *testing.T) {
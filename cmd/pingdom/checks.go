@@ -0,0 +1,409 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var checkCommands = map[string]command{
+	"list":   {"[-tags a,b]", listChecks},
+	"get":    {"<id>", getCheck},
+	"create": {"-type http|ping|tcp -name NAME -host HOST [flags]", createCheck},
+	"update": {"<id> [flags]", updateCheck},
+	"delete": {"<id>", deleteCheck},
+	"pause":  {"<id> [<id>...]", pauseChecks},
+	"resume": {"<id> [<id>...]", resumeChecks},
+}
+
+// checkFlags holds the flags shared by check create and update.
+type checkFlags struct {
+	typ              string
+	name             string
+	host             string
+	resolution       int
+	paused           bool
+	url              string
+	port             int
+	encryption       bool
+	shouldContain    string
+	shouldNotContain string
+	tags             string
+	probeFilters     string
+	userIDs          string
+	teamIDs          string
+	stringToSend     string
+	stringToExpect   string
+}
+
+func (f *checkFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.typ, "type", "http", "check type: http, ping or tcp")
+	fs.StringVar(&f.name, "name", "", "check name")
+	fs.StringVar(&f.host, "host", "", "target hostname")
+	fs.IntVar(&f.resolution, "resolution", 5, "check interval in minutes: 1, 5, 15, 30 or 60")
+	fs.BoolVar(&f.paused, "paused", false, "create or update the check in paused state")
+	fs.StringVar(&f.url, "url", "", "path to request (http)")
+	fs.IntVar(&f.port, "port", 0, "target port (http, tcp)")
+	fs.BoolVar(&f.encryption, "encryption", false, "use HTTPS (http)")
+	fs.StringVar(&f.shouldContain, "should-contain", "", "string the response must contain (http)")
+	fs.StringVar(&f.shouldNotContain, "should-not-contain", "", "string the response must not contain (http)")
+	fs.StringVar(&f.tags, "tags", "", "comma separated list of tags")
+	fs.StringVar(&f.probeFilters, "probe-filters", "", "probe filters, e.g. \"region: EU\"")
+	fs.StringVar(&f.userIDs, "userids", "", "comma separated list of contact IDs to alert")
+	fs.StringVar(&f.teamIDs, "teamids", "", "comma separated list of team IDs to alert")
+	fs.StringVar(&f.stringToSend, "string-to-send", "", "string to send (tcp)")
+	fs.StringVar(&f.stringToExpect, "string-to-expect", "", "string to expect in response (tcp)")
+}
+
+// apply sets the fields of check named by the flags in set.  A nil set
+// applies every flag.
+func (f *checkFlags) apply(check pingdom.Check, set map[string]bool) error {
+	has := func(name string) bool { return set == nil || set[name] }
+
+	var userIDs, teamIDs []int
+	var err error
+	if has("userids") {
+		if userIDs, err = parseIntList(f.userIDs); err != nil {
+			return err
+		}
+	}
+	if has("teamids") {
+		if teamIDs, err = parseIntList(f.teamIDs); err != nil {
+			return err
+		}
+	}
+
+	switch c := check.(type) {
+	case *pingdom.HttpCheck:
+		setString(&c.Name, f.name, has("name"))
+		setString(&c.Hostname, f.host, has("host"))
+		setInt(&c.Resolution, f.resolution, has("resolution"))
+		setBool(&c.Paused, f.paused, has("paused"))
+		setString(&c.Url, f.url, has("url"))
+		setInt(&c.Port, f.port, has("port"))
+		setBool(&c.Encryption, f.encryption, has("encryption"))
+		setString(&c.ShouldContain, f.shouldContain, has("should-contain"))
+		setString(&c.ShouldNotContain, f.shouldNotContain, has("should-not-contain"))
+		setString(&c.Tags, f.tags, has("tags"))
+		setString(&c.ProbeFilters, f.probeFilters, has("probe-filters"))
+		setInts(&c.UserIds, userIDs, has("userids"))
+		setInts(&c.TeamIds, teamIDs, has("teamids"))
+	case *pingdom.PingCheck:
+		setString(&c.Name, f.name, has("name"))
+		setString(&c.Hostname, f.host, has("host"))
+		setInt(&c.Resolution, f.resolution, has("resolution"))
+		setBool(&c.Paused, f.paused, has("paused"))
+		setString(&c.Tags, f.tags, has("tags"))
+		setString(&c.ProbeFilters, f.probeFilters, has("probe-filters"))
+		setInts(&c.UserIds, userIDs, has("userids"))
+		setInts(&c.TeamIds, teamIDs, has("teamids"))
+	case *pingdom.TCPCheck:
+		setString(&c.Name, f.name, has("name"))
+		setString(&c.Hostname, f.host, has("host"))
+		setInt(&c.Resolution, f.resolution, has("resolution"))
+		setBool(&c.Paused, f.paused, has("paused"))
+		setInt(&c.Port, f.port, has("port"))
+		setString(&c.StringToSend, f.stringToSend, has("string-to-send"))
+		setString(&c.StringToExpect, f.stringToExpect, has("string-to-expect"))
+		setString(&c.Tags, f.tags, has("tags"))
+		setString(&c.ProbeFilters, f.probeFilters, has("probe-filters"))
+		setInts(&c.UserIds, userIDs, has("userids"))
+		setInts(&c.TeamIds, teamIDs, has("teamids"))
+	}
+	return nil
+}
+
+func setString(dst *string, v string, ok bool) {
+	if ok {
+		*dst = v
+	}
+}
+
+func setInt(dst *int, v int, ok bool) {
+	if ok {
+		*dst = v
+	}
+}
+
+func setBool(dst *bool, v bool, ok bool) {
+	if ok {
+		*dst = v
+	}
+}
+
+func setInts(dst *[]int, v []int, ok bool) {
+	if ok {
+		*dst = v
+	}
+}
+
+func newCheck(typ string) (pingdom.Check, error) {
+	switch typ {
+	case "http":
+		return &pingdom.HttpCheck{}, nil
+	case "ping":
+		return &pingdom.PingCheck{}, nil
+	case "tcp":
+		return &pingdom.TCPCheck{}, nil
+	}
+	return nil, fmt.Errorf("unsupported check type %q", typ)
+}
+
+// checkFromResponse converts a detailed check as returned by
+// CheckService.Read into a Check that can be submitted to
+// CheckService.Update.
+func checkFromResponse(r *pingdom.CheckResponse) (pingdom.Check, error) {
+	tags := r.TagSet().String()
+
+	switch r.Type.Name {
+	case "http":
+		c := &pingdom.HttpCheck{
+			Name:                     r.Name,
+			Hostname:                 r.Hostname,
+			Resolution:               r.Resolution,
+			Paused:                   r.Paused,
+			SendNotificationWhenDown: r.SendNotificationWhenDown,
+			NotifyAgainEvery:         r.NotifyAgainEvery,
+			NotifyWhenBackup:         r.NotifyWhenBackup,
+			IntegrationIds:           r.IntegrationIds,
+			ResponseTimeThreshold:    r.ResponseTimeThreshold,
+			Tags:                     tags,
+			ProbeFilters:             strings.Join(r.ProbeFilters, ","),
+			UserIds:                  r.UserIds,
+			TeamIds:                  r.TeamIds,
+		}
+		if d := r.Type.HTTP; d != nil {
+			c.Url = d.Url
+			c.Encryption = d.Encryption
+			c.Port = d.Port
+			// An empty password would be sent as "auth=user:" and clear
+			// the stored credentials, so only carry over complete ones.
+			if d.Username != "" && d.Password != "" {
+				c.Username = d.Username
+				c.Password = d.Password
+			}
+			c.ShouldContain = d.ShouldContain
+			c.ShouldNotContain = d.ShouldNotContain
+			c.PostData = d.PostData
+			c.RequestHeaders = d.RequestHeaders
+		}
+		return c, nil
+	case "ping":
+		return &pingdom.PingCheck{
+			Name:                     r.Name,
+			Hostname:                 r.Hostname,
+			Resolution:               r.Resolution,
+			Paused:                   r.Paused,
+			SendNotificationWhenDown: r.SendNotificationWhenDown,
+			NotifyAgainEvery:         r.NotifyAgainEvery,
+			NotifyWhenBackup:         r.NotifyWhenBackup,
+			IntegrationIds:           r.IntegrationIds,
+			ResponseTimeThreshold:    r.ResponseTimeThreshold,
+			Tags:                     tags,
+			ProbeFilters:             strings.Join(r.ProbeFilters, ","),
+			UserIds:                  r.UserIds,
+			TeamIds:                  r.TeamIds,
+		}, nil
+	case "tcp":
+		c := &pingdom.TCPCheck{
+			Name:                     r.Name,
+			Hostname:                 r.Hostname,
+			Resolution:               r.Resolution,
+			Paused:                   r.Paused,
+			SendNotificationWhenDown: r.SendNotificationWhenDown,
+			NotifyAgainEvery:         r.NotifyAgainEvery,
+			NotifyWhenBackup:         r.NotifyWhenBackup,
+			IntegrationIds:           r.IntegrationIds,
+			Tags:                     tags,
+			ProbeFilters:             strings.Join(r.ProbeFilters, ","),
+			UserIds:                  r.UserIds,
+			TeamIds:                  r.TeamIds,
+		}
+		if d := r.Type.TCP; d != nil {
+			c.Port = d.Port
+			c.StringToSend = d.StringToSend
+			c.StringToExpect = d.StringToExpect
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("updating %q checks is not supported", r.Type.Name)
+}
+
+func listChecks(a *app, args []string) error {
+	fs := a.newFlagSet("checks list")
+	tags := fs.String("tags", "", "only list checks with any of these comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	params := map[string]string{"include_tags": "true"}
+	if *tags != "" {
+		params["tags"] = *tags
+	}
+	checks, err := a.client.Checks.List(params)
+	if err != nil {
+		return err
+	}
+
+	return a.render(checks, func() *table {
+		t := &table{header: []string{"ID", "NAME", "TYPE", "HOST", "STATUS", "RESOLUTION", "LAST RESPONSE", "TAGS"}}
+		for _, c := range checks {
+			t.add(strconv.Itoa(c.ID), c.Name, c.Type.Name, c.Hostname, c.Status,
+				strconv.Itoa(c.Resolution), strconv.FormatInt(c.LastResponseTime, 10), c.TagSet().String())
+		}
+		return t
+	})
+}
+
+func getCheck(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	check, err := a.client.Checks.Read(id)
+	if err != nil {
+		return err
+	}
+
+	return a.render(check, func() *table {
+		t := &table{header: []string{"FIELD", "VALUE"}}
+		t.add("ID", strconv.Itoa(check.ID))
+		t.add("Name", check.Name)
+		t.add("Type", check.Type.Name)
+		t.add("Host", check.Hostname)
+		t.add("Status", check.Status)
+		t.add("Paused", strconv.FormatBool(check.Paused))
+		t.add("Resolution", strconv.Itoa(check.Resolution))
+		t.add("Created", formatTime(check.Created))
+		t.add("Last test", formatTime(check.LastTestTime))
+		t.add("Last error", formatTime(check.LastErrorTime))
+		t.add("Last response (ms)", strconv.FormatInt(check.LastResponseTime, 10))
+		t.add("Tags", check.TagSet().String())
+		t.add("Probe filters", strings.Join(check.ProbeFilters, ","))
+		t.add("User IDs", formatInts(check.UserIds))
+		t.add("Team IDs", formatInts(check.TeamIds))
+		return t
+	})
+}
+
+func createCheck(a *app, args []string) error {
+	fs := a.newFlagSet("checks create")
+	var f checkFlags
+	f.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	check, err := newCheck(f.typ)
+	if err != nil {
+		return err
+	}
+	if err := f.apply(check, nil); err != nil {
+		return err
+	}
+
+	created, err := a.client.Checks.Create(check)
+	if err != nil {
+		return err
+	}
+	return a.render(created, func() *table {
+		t := &table{header: []string{"ID", "NAME"}}
+		t.add(strconv.Itoa(created.ID), created.Name)
+		return t
+	})
+}
+
+func updateCheck(a *app, args []string) error {
+	fs := a.newFlagSet("checks update")
+	var f checkFlags
+	f.register(fs)
+	if len(args) == 0 {
+		return fmt.Errorf("expected a check ID")
+	}
+	id, err := parseIntArg(args[0])
+	if err != nil {
+		return err
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// Pingdom expects the complete check on update, so start from the
+	// current state and only change what was given on the command line.
+	current, err := a.client.Checks.Read(id)
+	if err != nil {
+		return err
+	}
+	check, err := checkFromResponse(current)
+	if err != nil {
+		return err
+	}
+	if err := f.apply(check, flagsSet(fs)); err != nil {
+		return err
+	}
+
+	msg, err := a.client.Checks.Update(id, check)
+	if err != nil {
+		return err
+	}
+	return a.renderMessage(msg)
+}
+
+func deleteCheck(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	msg, err := a.client.Checks.Delete(id)
+	if err != nil {
+		return err
+	}
+	return a.renderMessage(msg)
+}
+
+func pauseChecks(a *app, args []string) error {
+	return setChecksPaused(a, args, true)
+}
+
+func resumeChecks(a *app, args []string) error {
+	return setChecksPaused(a, args, false)
+}
+
+// setChecksPaused uses the bulk modify endpoint, which only changes the
+// paused state and leaves all other check settings untouched.
+func setChecksPaused(a *app, args []string, paused bool) error {
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	req, err := a.client.NewRequest("PUT", "/checks", map[string]string{
+		"checkids": formatInts(ids),
+		"paused":   strconv.FormatBool(paused),
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := &pingdom.PingdomResponse{}
+	if _, err := a.client.Do(req, msg); err != nil {
+		return err
+	}
+	return a.renderMessage(msg)
+}
+
+func (a *app) renderMessage(msg interface{}) error {
+	return a.render(msg, func() *table {
+		t := &table{header: []string{"MESSAGE"}}
+		switch m := msg.(type) {
+		case *pingdom.PingdomResponse:
+			t.add(m.Message)
+		case *pingdom.TeamDeleteResponse:
+			t.add(m.Message)
+		}
+		return t
+	})
+}
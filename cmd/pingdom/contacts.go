@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var contactCommands = map[string]command{
+	"list":   {"", listContacts},
+	"get":    {"<id>", getContact},
+	"create": {"-name NAME [-email ADDR]... [-sms CC:NUMBER:PROVIDER]... [-severity HIGH|LOW]", createContact},
+	"delete": {"<id>", deleteContact},
+}
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func contactTargets(c pingdom.Contact) string {
+	var targets []string
+	for _, e := range c.NotificationTargets.Email {
+		targets = append(targets, fmt.Sprintf("email:%s(%s)", e.Address, e.Severity))
+	}
+	for _, s := range c.NotificationTargets.SMS {
+		targets = append(targets, fmt.Sprintf("sms:+%s %s(%s)", s.CountryCode, s.Number, s.Severity))
+	}
+	return strings.Join(targets, ",")
+}
+
+func listContacts(a *app, args []string) error {
+	if err := a.newFlagSet("contacts list").Parse(args); err != nil {
+		return err
+	}
+	contacts, err := a.client.Contacts.List()
+	if err != nil {
+		return err
+	}
+
+	return a.render(contacts, func() *table {
+		t := &table{header: []string{"ID", "NAME", "TYPE", "PAUSED", "TARGETS"}}
+		for _, c := range contacts {
+			t.add(strconv.Itoa(c.ID), c.Name, c.Type, strconv.FormatBool(c.Paused), contactTargets(c))
+		}
+		return t
+	})
+}
+
+func getContact(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	contact, err := a.client.Contacts.Read(id)
+	if err != nil {
+		return err
+	}
+
+	return a.render(contact, func() *table {
+		t := &table{header: []string{"ID", "NAME", "TYPE", "PAUSED", "TARGETS"}}
+		t.add(strconv.Itoa(contact.ID), contact.Name, contact.Type, strconv.FormatBool(contact.Paused), contactTargets(*contact))
+		return t
+	})
+}
+
+func createContact(a *app, args []string) error {
+	fs := a.newFlagSet("contacts create")
+	name := fs.String("name", "", "contact name")
+	paused := fs.Bool("paused", false, "create the contact in paused state")
+	severity := fs.String("severity", "HIGH", "severity of the given targets: HIGH or LOW")
+	var emails, sms stringList
+	fs.Var(&emails, "email", "email address to notify (repeatable)")
+	fs.Var(&sms, "sms", "phone to notify as COUNTRYCODE:NUMBER:PROVIDER (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	contact := pingdom.Contact{Name: *name, Paused: *paused}
+	for _, e := range emails {
+		contact.NotificationTargets.Email = append(contact.NotificationTargets.Email, pingdom.EmailNotification{
+			Address:  e,
+			Severity: *severity,
+		})
+	}
+	for _, s := range sms {
+		parts := strings.SplitN(s, ":", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid sms target %q, expected COUNTRYCODE:NUMBER:PROVIDER", s)
+		}
+		contact.NotificationTargets.SMS = append(contact.NotificationTargets.SMS, pingdom.SMSNotification{
+			CountryCode: parts[0],
+			Number:      parts[1],
+			Provider:    parts[2],
+			Severity:    *severity,
+		})
+	}
+
+	created, err := a.client.Contacts.Create(&contact)
+	if err != nil {
+		return err
+	}
+	return a.render(created, func() *table {
+		t := &table{header: []string{"ID"}}
+		t.add(strconv.Itoa(created.ID))
+		return t
+	})
+}
+
+func deleteContact(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	msg, err := a.client.Contacts.Delete(id)
+	if err != nil {
+		return err
+	}
+	return a.renderMessage(msg)
+}
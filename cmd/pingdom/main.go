@@ -0,0 +1,196 @@
+// Command pingdom is a command-line interface to the Pingdom API.
+//
+// Usage:
+//
+//	pingdom [global flags] <resource> <command> [flags] [args]
+//
+// The API token is read from the -token flag or, if that is not set, from
+// the PINGDOM_API_TOKEN environment variable.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// command is a single action on a resource such as "checks list".
+type command struct {
+	usage string
+	run   func(a *app, args []string) error
+}
+
+// app holds the state shared by all commands.
+type app struct {
+	client *pingdom.Client
+	out    io.Writer
+	errOut io.Writer
+	format string
+}
+
+var resources = map[string]map[string]command{
+	"checks":      checkCommands,
+	"contacts":    contactCommands,
+	"teams":       teamCommands,
+	"maintenance": maintenanceCommands,
+	"probes":      probeCommands,
+	"results":     resultCommands,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, out, errOut io.Writer) int {
+	fs := flag.NewFlagSet("pingdom", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	token := fs.String("token", "", "Pingdom API token (defaults to $PINGDOM_API_TOKEN)")
+	baseURL := fs.String("base-url", "", "Pingdom API base URL")
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if !validFormat(*format) {
+		fmt.Fprintf(errOut, "unknown output format %q\n", *format)
+		return 2
+	}
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return 2
+	}
+
+	commands, ok := resources[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(errOut, "unknown resource %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+	cmd, ok := commands[fs.Arg(1)]
+	if !ok {
+		fmt.Fprintf(errOut, "unknown command %q for %s\n", fs.Arg(1), fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken: *token,
+		BaseURL:  *baseURL,
+	})
+	if err != nil {
+		fmt.Fprintln(errOut, "Error:", err)
+		return 1
+	}
+	if client.APIToken == "" {
+		fmt.Fprintln(errOut, "Error: no API token, set -token or PINGDOM_API_TOKEN")
+		return 1
+	}
+
+	a := &app{client: client, out: out, errOut: errOut, format: *format}
+	if err := cmd.run(a, fs.Args()[2:]); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(errOut, "Error:", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: pingdom [global flags] <resource> <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	var names []string
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var cmds []string
+		for cmd := range resources[name] {
+			cmds = append(cmds, cmd)
+		}
+		sort.Strings(cmds)
+		for _, cmd := range cmds {
+			fmt.Fprintf(w, "  %s %s %s\n", name, cmd, resources[name][cmd].usage)
+		}
+	}
+}
+
+// newFlagSet returns a FlagSet for a command that reports errors to the app.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.errOut)
+	return fs
+}
+
+// parseID parses the single positional ID argument of a command.
+func parseID(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("expected exactly one ID argument, got %d", len(args))
+	}
+	return parseIntArg(args[0])
+}
+
+// parseIDs parses one or more positional ID arguments of a command.
+func parseIDs(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("expected at least one ID argument")
+	}
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := parseIntArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func parseIntArg(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q", s)
+	}
+	return id, nil
+}
+
+// parseIntList parses a comma separated list of integers such as "1,2,3".
+func parseIntList(s string) ([]int, error) {
+	if s == "" {
+		return []int{}, nil
+	}
+	parts := strings.Split(s, ",")
+	ids := make([]int, len(parts))
+	for i, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q in list", part)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// flagsSet returns the names of the flags that were set on the command line.
+func flagsSet(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
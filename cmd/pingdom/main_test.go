@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mux    *http.ServeMux
+	server *httptest.Server
+)
+
+func setup() {
+	mux = http.NewServeMux()
+	server = httptest.NewServer(mux)
+}
+
+func teardown() {
+	server.Close()
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var out, errOut bytes.Buffer
+	args = append([]string{"-token", "my_api_key", "-base-url", server.URL}, args...)
+	code := run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+const checkDetailsJSON = `{
+	"check": {
+		"id": 85975,
+		"name": "My check",
+		"resolution": 1,
+		"hostname": "example.com",
+		"status": "up",
+		"type": {
+			"http": {
+				"url": "/health",
+				"port": 443,
+				"encryption": true
+			}
+		},
+		"tags": [{"name": "apache", "type": "u", "count": 1}]
+	}
+}`
+
+func TestChecksListTable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "Bearer my_api_key", r.Header.Get("Authorization"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		fmt.Fprint(w, `{"checks": [{"id": 85975, "name": "My check", "type": "http", "hostname": "example.com", "status": "up", "resolution": 1}]}`)
+	})
+
+	code, out, _ := runCLI("checks", "list")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "ID     NAME")
+	assert.Contains(t, out, "85975  My check  http")
+}
+
+func TestChecksGetJSON(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/85975", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, checkDetailsJSON)
+	})
+
+	code, out, _ := runCLI("-o", "json", "checks", "get", "85975")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, `"id": 85975`)
+	assert.Contains(t, out, `"url": "/health"`)
+}
+
+func TestChecksGetYAML(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/85975", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, checkDetailsJSON)
+	})
+
+	code, out, _ := runCLI("-o", "yaml", "checks", "get", "85975")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "id: 85975\n")
+	assert.Contains(t, out, "url: /health\n")
+}
+
+func TestChecksUpdateKeepsUnsetFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/85975", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, checkDetailsJSON)
+			return
+		}
+		assert.Equal(t, "PUT", r.Method)
+		q := r.URL.Query()
+		assert.Equal(t, "Renamed", q.Get("name"))
+		assert.Equal(t, "example.com", q.Get("host"))
+		assert.Equal(t, "/health", q.Get("url"))
+		assert.Equal(t, "true", q.Get("encryption"))
+		assert.Equal(t, "apache", q.Get("tags"))
+		fmt.Fprint(w, `{"message": "Modification of check was successful!"}`)
+	})
+
+	code, out, errOut := runCLI("checks", "update", "85975", "-name", "Renamed")
+	assert.Equal(t, 0, code, errOut)
+	assert.Contains(t, out, "Modification of check was successful!")
+}
+
+func TestChecksPause(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "1,2", r.URL.Query().Get("checkids"))
+		assert.Equal(t, "true", r.URL.Query().Get("paused"))
+		fmt.Fprint(w, `{"message": "Modification of 2 checks was successful!"}`)
+	})
+
+	code, out, _ := runCLI("checks", "pause", "1", "2")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "Modification of 2 checks was successful!")
+}
+
+func TestAPIErrorIsReported(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"statuscode": 404, "statusdesc": "Not Found", "errormessage": "Check not found"}}`)
+	})
+
+	code, _, errOut := runCLI("checks", "get", "1")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "404 Not Found: Check not found")
+}
+
+func TestUsageErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	code, _, errOut := runCLI("widgets", "list")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, errOut, `unknown resource "widgets"`)
+
+	code, _, errOut = runCLI("-o", "xml", "checks", "list")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, errOut, `unknown output format "xml"`)
+
+	code, _, errOut = runCLI("checks", "get")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "expected exactly one ID argument")
+}
+
+func TestProbesAllowlist(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("onlyactive"))
+		fmt.Fprint(w, `{"probes": [
+			{"id": 1, "active": true, "ip": "10.0.0.1", "ipv6": "2001:db8::1", "region": "EU"},
+			{"id": 2, "active": true, "ip": "10.0.0.2", "region": "NA"}
+		]}`)
+	})
+
+	code, out, _ := runCLI("probes", "allowlist", "-format", "nginx", "-region", "eu")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "# Pingdom probes\nallow 10.0.0.1/32;\nallow 2001:db8::1/128;\n", out)
+
+	previous := filepath.Join(t.TempDir(), "pingdom.conf")
+	assert.NoError(t, os.WriteFile(previous, []byte("allow 10.0.0.1/32;\nallow 10.0.0.3/32;\n"), 0o644))
+	code, out, _ = runCLI("probes", "allowlist", "-diff", previous)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "+10.0.0.2/32\n+2001:db8::1/128\n-10.0.0.3/32\n", out)
+
+	code, _, errOut := runCLI("probes", "allowlist", "-region", "MARS")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "MARS")
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var maintenanceCommands = map[string]command{
+	"list":   {"", listMaintenance},
+	"get":    {"<id>", getMaintenance},
+	"create": {"-description DESC -from RFC3339 -to RFC3339 [-checks 1,2]", createMaintenance},
+	"delete": {"<id>", deleteMaintenance},
+}
+
+func maintenanceTable(windows ...pingdom.MaintenanceResponse) *table {
+	t := &table{header: []string{"ID", "DESCRIPTION", "FROM", "TO", "RECURRENCE", "CHECKS"}}
+	for _, m := range windows {
+		t.add(strconv.Itoa(m.ID), m.Description, formatTime(m.From), formatTime(m.To),
+			m.RecurrenceType, formatInts(m.Checks.Uptime))
+	}
+	return t
+}
+
+func listMaintenance(a *app, args []string) error {
+	if err := a.newFlagSet("maintenance list").Parse(args); err != nil {
+		return err
+	}
+	windows, err := a.client.Maintenances.List()
+	if err != nil {
+		return err
+	}
+	return a.render(windows, func() *table { return maintenanceTable(windows...) })
+}
+
+func getMaintenance(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	window, err := a.client.Maintenances.Read(id)
+	if err != nil {
+		return err
+	}
+	return a.render(window, func() *table { return maintenanceTable(*window) })
+}
+
+func createMaintenance(a *app, args []string) error {
+	fs := a.newFlagSet("maintenance create")
+	description := fs.String("description", "", "description of the maintenance window")
+	from := fs.String("from", "", "start of the window in RFC3339 format")
+	to := fs.String("to", "", "end of the window in RFC3339 format")
+	checks := fs.String("checks", "", "comma separated list of uptime check IDs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	window := pingdom.MaintenanceWindow{Description: *description}
+	if *from != "" {
+		t, err := time.Parse(time.RFC3339, *from)
+		if err != nil {
+			return err
+		}
+		window.From = t.Unix()
+	}
+	if *to != "" {
+		t, err := time.Parse(time.RFC3339, *to)
+		if err != nil {
+			return err
+		}
+		window.To = t.Unix()
+	}
+	if *checks != "" {
+		ids, err := parseIntList(*checks)
+		if err != nil {
+			return err
+		}
+		window.UptimeIDs = formatInts(ids)
+	}
+
+	created, err := a.client.Maintenances.Create(&window)
+	if err != nil {
+		return err
+	}
+	return a.render(created, func() *table { return maintenanceTable(*created) })
+}
+
+func deleteMaintenance(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	msg, err := a.client.Maintenances.Delete(id)
+	if err != nil {
+		return err
+	}
+	return a.renderMessage(msg)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/allowlist"
+)
+
+var probeCommands = map[string]command{
+	"list":      {"[-active]", listProbes},
+	"allowlist": {"[-format cidr|nginx|iptables|nftables] [-region EU,NA] [-diff file]", probeAllowlist},
+}
+
+var allowlistFormats = map[string]allowlist.Format{
+	"cidr":     allowlist.CIDR,
+	"nginx":    allowlist.Nginx,
+	"iptables": allowlist.IPTables,
+	"nftables": allowlist.NFTables,
+}
+
+func listProbes(a *app, args []string) error {
+	fs := a.newFlagSet("probes list")
+	active := fs.Bool("active", false, "only list active probes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	params := map[string]string{}
+	if *active {
+		params["onlyactive"] = "true"
+	}
+	probes, err := a.client.Probes.List(params)
+	if err != nil {
+		return err
+	}
+
+	return a.render(probes, func() *table { return probeTable(probes) })
+}
+
+func probeTable(probes []pingdom.ProbeResponse) *table {
+	t := &table{header: []string{"ID", "NAME", "REGION", "COUNTRY", "ACTIVE", "IP", "IPV6"}}
+	for _, p := range probes {
+		t.add(strconv.Itoa(p.ID), p.Name, p.Region, p.CountryISO, strconv.FormatBool(p.Active), p.IP, p.IPv6)
+	}
+	return t
+}
+
+// probeAllowlist writes firewall rules allowing the active probes or, with
+// -diff, the changes since a previously generated list.
+func probeAllowlist(a *app, args []string) error {
+	fs := a.newFlagSet("probes allowlist")
+	format := fs.String("format", "cidr", "rule format: cidr, nginx, iptables or nftables")
+	regions := fs.String("region", "", "comma-separated regions of the probes to allow")
+	diff := fs.String("diff", "", "show the changes since the list in this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	f, ok := allowlistFormats[*format]
+	if !ok {
+		return fmt.Errorf("unknown allowlist format %q", *format)
+	}
+
+	var filter []pingdom.Region
+	if *regions != "" {
+		for _, r := range strings.Split(*regions, ",") {
+			filter = append(filter, pingdom.Region(strings.ToUpper(strings.TrimSpace(r))))
+		}
+		if err := pingdom.NewProbeFilter(filter...).Valid(); err != nil {
+			return err
+		}
+	}
+
+	list, err := allowlist.Fetch(a.client.Probes, filter...)
+	if err != nil {
+		return err
+	}
+	if *diff == "" {
+		return list.Write(a.out, f)
+	}
+
+	file, err := os.Open(*diff)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	previous, err := allowlist.Parse(file)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(a.out, allowlist.Compare(previous, list))
+	return err
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var resultCommands = map[string]command{
+	"list": {"<check id> [-from RFC3339] [-to RFC3339] [-limit N] [-probes 1,2] [-status up,down]", listResults},
+}
+
+func listResults(a *app, args []string) error {
+	fs := a.newFlagSet("results list")
+	from := fs.String("from", "", "start of the period in RFC3339 format")
+	to := fs.String("to", "", "end of the period in RFC3339 format")
+	limit := fs.Int("limit", 0, "maximum number of results to return")
+	probes := fs.String("probes", "", "comma separated list of probe IDs")
+	status := fs.String("status", "", "comma separated list of statuses, e.g. down,unconfirmed")
+	if len(args) == 0 {
+		return fmt.Errorf("expected a check ID")
+	}
+	id, err := parseIntArg(args[0])
+	if err != nil {
+		return err
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	params := map[string]string{}
+	for name, v := range map[string]string{"from": *from, "to": *to} {
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return err
+		}
+		params[name] = strconv.FormatInt(t.Unix(), 10)
+	}
+	if *limit > 0 {
+		params["limit"] = strconv.Itoa(*limit)
+	}
+	if *probes != "" {
+		params["probes"] = *probes
+	}
+	if *status != "" {
+		params["status"] = *status
+	}
+
+	results, err := a.client.Checks.Results(id, params)
+	if err != nil {
+		return err
+	}
+
+	return a.render(results, func() *table {
+		t := &table{header: []string{"TIME", "PROBE", "STATUS", "RESPONSE TIME", "DESCRIPTION"}}
+		for _, r := range results.Results {
+			t.add(formatTime(int64(r.Time)), strconv.Itoa(r.ProbeID), r.Status,
+				strconv.Itoa(r.ResponseTime), r.StatusDesc)
+		}
+		return t
+	})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var teamCommands = map[string]command{
+	"list":   {"", listTeams},
+	"get":    {"<id>", getTeam},
+	"create": {"-name NAME [-members 1,2]", createTeam},
+	"update": {"<id> -name NAME [-members 1,2]", updateTeam},
+	"delete": {"<id>", deleteTeam},
+}
+
+func teamMembers(members []pingdom.TeamMemberResponse) string {
+	names := make([]string, len(members))
+	for i, m := range members {
+		names[i] = strconv.Itoa(m.ID) + ":" + m.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func teamTable(teams ...pingdom.TeamResponse) *table {
+	t := &table{header: []string{"ID", "NAME", "MEMBERS"}}
+	for _, team := range teams {
+		t.add(strconv.Itoa(team.ID), team.Name, teamMembers(team.Members))
+	}
+	return t
+}
+
+func listTeams(a *app, args []string) error {
+	if err := a.newFlagSet("teams list").Parse(args); err != nil {
+		return err
+	}
+	teams, err := a.client.Teams.List()
+	if err != nil {
+		return err
+	}
+	return a.render(teams, func() *table { return teamTable(teams...) })
+}
+
+func getTeam(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	team, err := a.client.Teams.Read(id)
+	if err != nil {
+		return err
+	}
+	return a.render(team, func() *table { return teamTable(*team) })
+}
+
+func parseTeamFlags(a *app, name string, args []string) (*pingdom.Team, error) {
+	fs := a.newFlagSet(name)
+	teamName := fs.String("name", "", "team name")
+	members := fs.String("members", "", "comma separated list of contact IDs")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	ids, err := parseIntList(*members)
+	if err != nil {
+		return nil, err
+	}
+	return &pingdom.Team{Name: *teamName, MemberIDs: ids}, nil
+}
+
+func createTeam(a *app, args []string) error {
+	team, err := parseTeamFlags(a, "teams create", args)
+	if err != nil {
+		return err
+	}
+	created, err := a.client.Teams.Create(team)
+	if err != nil {
+		return err
+	}
+	return a.render(created, func() *table { return teamTable(*created) })
+}
+
+func updateTeam(a *app, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected a team ID")
+	}
+	id, err := parseIntArg(args[0])
+	if err != nil {
+		return err
+	}
+	team, err := parseTeamFlags(a, "teams update", args[1:])
+	if err != nil {
+		return err
+	}
+	updated, err := a.client.Teams.Update(id, team)
+	if err != nil {
+		return err
+	}
+	return a.render(updated, func() *table { return teamTable(*updated) })
+}
+
+func deleteTeam(a *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+	msg, err := a.client.Teams.Delete(id)
+	if err != nil {
+		return err
+	}
+	return a.renderMessage(msg)
+}
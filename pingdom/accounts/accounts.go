@@ -0,0 +1,262 @@
+/*
+Package accounts manages clients for several Pingdom accounts and queries
+them together.
+
+	m := accounts.New()
+	m.Add("prod", pingdom.ClientConfig{APIToken: prodToken})
+	m.Add("staging", pingdom.ClientConfig{APIToken: stagingToken})
+
+	checks, err := m.ListChecks()
+	for _, c := range checks {
+		fmt.Println(c.Account, c.Name, c.Status)
+	}
+	if err != nil {
+		// Checks of the accounts that did not fail are still returned.
+		log.Println(err)
+	}
+
+Queries run concurrently, one per account.  Errors are collected per
+account in an Errors value.
+*/
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Errors maps the names of accounts to the errors of a query.
+type Errors map[string]error
+
+func (e Errors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("account %s: %v", name, e[name])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the accounts in ascending order of name.
+func (e Errors) Unwrap() []error {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	errs := make([]error, len(names))
+	for i, name := range names {
+		errs[i] = e[name]
+	}
+	return errs
+}
+
+// Is reports whether the error of any account matches target, so that
+// errors.Is works before Go 1.20, which does not follow Unwrap() []error.
+func (e Errors) Is(target error) bool {
+	for _, err := range e.Unwrap() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of an account, in ascending order of name, that
+// matches target, in the same way as Is.
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e.Unwrap() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Manager holds named clients.  It is safe for concurrent use.
+type Manager struct {
+	mu      sync.RWMutex
+	clients map[string]*pingdom.Client
+}
+
+// New returns a Manager without any account.
+func New() *Manager {
+	return &Manager{clients: map[string]*pingdom.Client{}}
+}
+
+// Add creates a client for an account from config, which must set APIToken
+// or TokenSource.  Unlike NewClientWithConfig, Add does not fall back to the
+// PINGDOM_API_TOKEN environment variable, which would make the account
+// query whichever account the environment belongs to.
+func (m *Manager) Add(name string, config pingdom.ClientConfig) error {
+	if config.APIToken == "" && config.TokenSource == nil {
+		return fmt.Errorf("account %s: %w: set APIToken or TokenSource", name, pingdom.ErrNoToken)
+	}
+	client, err := pingdom.NewClientWithConfig(config)
+	if err != nil {
+		return fmt.Errorf("account %s: %v", name, err)
+	}
+	return m.AddClient(name, client)
+}
+
+// AddClient adds an existing client for an account.
+func (m *Manager) AddClient(name string, client *pingdom.Client) error {
+	if name == "" {
+		return fmt.Errorf("Invalid value for account name.  Must contain non-empty string")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.clients[name]; ok {
+		return fmt.Errorf("account %s already exists", name)
+	}
+	m.clients[name] = client
+	return nil
+}
+
+// Remove removes an account.
+func (m *Manager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, name)
+}
+
+// Client returns the client of an account.
+func (m *Manager) Client(name string) (*pingdom.Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	client, ok := m.clients[name]
+	return client, ok
+}
+
+// Names returns the names of the accounts in ascending order.
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.clients))
+	for name := range m.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Each calls fn concurrently for every account and waits for all calls to
+// return.  The returned error is an Errors value with the errors returned
+// by fn, or nil if all calls succeeded.
+func (m *Manager) Each(fn func(name string, client *pingdom.Client) error) error {
+	m.mu.RLock()
+	clients := make(map[string]*pingdom.Client, len(m.clients))
+	for name, client := range m.clients {
+		clients[name] = client
+	}
+	m.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := Errors{}
+	for name, client := range clients {
+		wg.Add(1)
+		go func(name string, client *pingdom.Client) {
+			defer wg.Done()
+			if err := fn(name, client); err != nil {
+				mu.Lock()
+				errs[name] = err
+				mu.Unlock()
+			}
+		}(name, client)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Check is a check of an account.
+type Check struct {
+	Account string
+	pingdom.CheckResponse
+}
+
+// Contact is a contact of an account.
+type Contact struct {
+	Account string
+	pingdom.Contact
+}
+
+// Team is a team of an account.
+type Team struct {
+	Account string
+	pingdom.TeamResponse
+}
+
+// ListChecks lists the checks of every account, ordered by account name and
+// then as returned by CheckService.List.  Params are passed to every call.
+// On error, the checks of the accounts that succeeded are returned along
+// with an Errors value.
+func (m *Manager) ListChecks(params ...map[string]string) ([]Check, error) {
+	return list(m, func(client *pingdom.Client) ([]pingdom.CheckResponse, error) {
+		return client.Checks.List(params...)
+	}, func(account string, c pingdom.CheckResponse) Check {
+		return Check{Account: account, CheckResponse: c}
+	})
+}
+
+// ListContacts lists the contacts of every account, in the same way as
+// ListChecks.
+func (m *Manager) ListContacts() ([]Contact, error) {
+	return list(m, func(client *pingdom.Client) ([]pingdom.Contact, error) {
+		return client.Contacts.List()
+	}, func(account string, c pingdom.Contact) Contact {
+		return Contact{Account: account, Contact: c}
+	})
+}
+
+// ListTeams lists the teams of every account, in the same way as
+// ListChecks.
+func (m *Manager) ListTeams() ([]Team, error) {
+	return list(m, func(client *pingdom.Client) ([]pingdom.TeamResponse, error) {
+		return client.Teams.List()
+	}, func(account string, t pingdom.TeamResponse) Team {
+		return Team{Account: account, TeamResponse: t}
+	})
+}
+
+// list calls fetch for every account and labels the items it returns with
+// the name of the account, ordered by account name.
+func list[T, L any](m *Manager, fetch func(*pingdom.Client) ([]T, error), label func(account string, item T) L) ([]L, error) {
+	lists := map[string][]T{}
+	var names []string
+	var mu sync.Mutex
+	err := m.Each(func(name string, client *pingdom.Client) error {
+		items, err := fetch(client)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		lists[name] = items
+		names = append(names, name)
+		mu.Unlock()
+		return nil
+	})
+
+	var all []L
+	sort.Strings(names)
+	for _, name := range names {
+		for _, item := range lists[name] {
+			all = append(all, label(name, item))
+		}
+	}
+	return all, err
+}
@@ -0,0 +1,182 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdomtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, token string, checks ...string) *pingdomtest.Server {
+	server := pingdomtest.NewServer(token)
+	client := server.Client()
+	for _, name := range checks {
+		_, err := client.Checks.Create(&pingdom.HttpCheck{Name: name, Hostname: "example.com", Resolution: 5})
+		assert.NoError(t, err)
+	}
+	return server
+}
+
+func TestAdd(t *testing.T) {
+	m := New()
+	assert.NoError(t, m.Add("staging", pingdom.ClientConfig{APIToken: "staging_token"}))
+	assert.NoError(t, m.Add("prod", pingdom.ClientConfig{APIToken: "prod_token"}))
+	assert.Equal(t, []string{"prod", "staging"}, m.Names())
+
+	client, ok := m.Client("prod")
+	assert.True(t, ok)
+	assert.Equal(t, "prod_token", client.APIToken)
+
+	assert.EqualError(t, m.Add("prod", pingdom.ClientConfig{APIToken: "token"}), "account prod already exists")
+	assert.Error(t, m.Add("", pingdom.ClientConfig{APIToken: "token"}))
+	assert.Error(t, m.Add("bad", pingdom.ClientConfig{APIToken: "token", BaseURL: ":"}))
+	assert.NoError(t, m.Add("rotated", pingdom.ClientConfig{TokenSource: pingdom.EnvToken("")}))
+
+	m.Remove("prod")
+	_, ok = m.Client("prod")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"rotated", "staging"}, m.Names())
+}
+
+func TestAddRequiresToken(t *testing.T) {
+	t.Setenv("PINGDOM_API_TOKEN", "other_account_token")
+	m := New()
+
+	err := m.Add("staging", pingdom.ClientConfig{})
+	assert.ErrorIs(t, err, pingdom.ErrNoToken)
+	assert.EqualError(t, err, "account staging: no API token: set APIToken or TokenSource")
+	assert.Empty(t, m.Names())
+}
+
+func TestEach(t *testing.T) {
+	m := New()
+	for _, name := range []string{"a", "b", "c"} {
+		assert.NoError(t, m.AddClient(name, &pingdom.Client{}))
+	}
+
+	var mu sync.Mutex
+	var called []string
+	failure := errors.New("failure")
+	err := m.Each(func(name string, client *pingdom.Client) error {
+		mu.Lock()
+		called = append(called, name)
+		mu.Unlock()
+		if name == "b" {
+			return failure
+		}
+		return nil
+	})
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, called)
+	assert.EqualError(t, err, "account b: failure")
+	assert.ErrorIs(t, err, failure)
+
+	var errs Errors
+	if assert.ErrorAs(t, err, &errs) {
+		assert.Len(t, errs, 1)
+	}
+
+	assert.NoError(t, m.Each(func(string, *pingdom.Client) error { return nil }))
+}
+
+func TestErrorsIsAs(t *testing.T) {
+	failure := errors.New("failure")
+	errs := Errors{
+		"a": &pingdom.PingdomError{StatusCode: 401},
+		"b": fmt.Errorf("wrapped: %w", failure),
+	}
+
+	// Call the methods directly, as errors.Is and errors.As only use them
+	// before Go 1.20.
+	assert.True(t, errs.Is(failure))
+	assert.False(t, errs.Is(errors.New("other")))
+
+	var pingdomErr *pingdom.PingdomError
+	assert.True(t, errs.As(&pingdomErr))
+	assert.Equal(t, 401, pingdomErr.StatusCode)
+	var pathErr *os.PathError
+	assert.False(t, errs.As(&pathErr))
+}
+
+func TestListChecks(t *testing.T) {
+	prod := newServer(t, "prod_token", "api", "web")
+	defer prod.Close()
+	staging := newServer(t, "staging_token", "api")
+	defer staging.Close()
+
+	m := New()
+	assert.NoError(t, m.AddClient("staging", staging.Client()))
+	assert.NoError(t, m.AddClient("prod", prod.Client()))
+
+	checks, err := m.ListChecks()
+	assert.NoError(t, err)
+	var got []string
+	for _, c := range checks {
+		got = append(got, c.Account+"/"+c.Name)
+	}
+	assert.Equal(t, []string{"prod/api", "prod/web", "staging/api"}, got)
+}
+
+func TestListChecks_PartialFailure(t *testing.T) {
+	prod := newServer(t, "prod_token", "api")
+	defer prod.Close()
+	staging := newServer(t, "staging_token", "api")
+	defer staging.Close()
+
+	m := New()
+	assert.NoError(t, m.AddClient("prod", prod.Client()))
+	assert.NoError(t, m.Add("staging", pingdom.ClientConfig{
+		APIToken:   "revoked_token",
+		BaseURL:    staging.URL,
+		HTTPClient: staging.Server.Client(),
+	}))
+
+	checks, err := m.ListChecks()
+	if assert.Len(t, checks, 1) {
+		assert.Equal(t, "prod", checks[0].Account)
+	}
+
+	var errs Errors
+	if assert.ErrorAs(t, err, &errs) {
+		assert.Len(t, errs, 1)
+		assert.Contains(t, errs, "staging")
+	}
+	var pingdomErr *pingdom.PingdomError
+	if assert.ErrorAs(t, err, &pingdomErr) {
+		assert.Equal(t, 401, pingdomErr.StatusCode)
+	}
+}
+
+func TestListContactsAndTeams(t *testing.T) {
+	prod := newServer(t, "prod_token")
+	defer prod.Close()
+	staging := newServer(t, "staging_token")
+	defer staging.Close()
+
+	contact, err := prod.Client().Contacts.Create(&pingdom.Contact{Name: "On call"})
+	assert.NoError(t, err)
+	_, err = staging.Client().Teams.Create(&pingdom.Team{Name: "Developers"})
+	assert.NoError(t, err)
+
+	m := New()
+	assert.NoError(t, m.AddClient("prod", prod.Client()))
+	assert.NoError(t, m.AddClient("staging", staging.Client()))
+
+	contacts, err := m.ListContacts()
+	assert.NoError(t, err)
+	if assert.Len(t, contacts, 1) {
+		assert.Equal(t, "prod", contacts[0].Account)
+		assert.Equal(t, contact.ID, contacts[0].ID)
+	}
+
+	teams, err := m.ListTeams()
+	assert.NoError(t, err)
+	if assert.Len(t, teams, 1) {
+		assert.Equal(t, "staging", teams[0].Account)
+		assert.Equal(t, "Developers", teams[0].Name)
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Package allowlist renders the addresses of Pingdom probes as firewall rules,
+so that servers can accept monitoring traffic only from Pingdom.
+
+	list, err := allowlist.Fetch(client.Probes, pingdom.RegionEU, pingdom.RegionNA)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = list.Write(os.Stdout, allowlist.Nginx)
+
+Review changes to the probe set before deploying them by comparing a fresh
+list with the previously generated one:
+
+	f, err := os.Open("/etc/nginx/pingdom.conf")
+	previous, err := allowlist.Parse(f)
+	fmt.Print(allowlist.Compare(previous, list))
+*/
+package allowlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Format is an output format of a List.
+type Format int
+
+const (
+	// CIDR lists one prefix per line.
+	CIDR Format = iota
+
+	// Nginx renders an allow directive per prefix, to be included in a
+	// location block followed by "deny all;".
+	Nginx
+
+	// IPTables renders iptables and ip6tables commands appending a rule
+	// accepting each prefix to the pingdom-probes chain, which must exist.
+	IPTables
+
+	// NFTables renders an inet table named pingdom with the sets probes4
+	// and probes6, to be referenced from rules such as
+	// "ip saddr @probes4 accept".
+	NFTables
+)
+
+// List is a sorted set of IPv4 and IPv6 prefixes.
+type List struct {
+	IPv4 []netip.Prefix
+	IPv6 []netip.Prefix
+}
+
+// FromProbes returns the addresses of the active probes in the given
+// regions, or in every region if none is given.
+func FromProbes(probes []pingdom.ProbeResponse, regions ...pingdom.Region) *List {
+	wanted := map[pingdom.Region]bool{}
+	for _, r := range regions {
+		wanted[r] = true
+	}
+
+	var prefixes []netip.Prefix
+	for _, p := range probes {
+		if !p.Active || (len(wanted) > 0 && !wanted[pingdom.Region(p.Region)]) {
+			continue
+		}
+		for _, ip := range []string{p.IP, p.IPv6} {
+			if addr, err := netip.ParseAddr(ip); err == nil {
+				prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			}
+		}
+	}
+	return New(prefixes...)
+}
+
+// Fetch lists the probes and returns the addresses of the active probes in
+// the given regions, or in every region if none is given.
+func Fetch(probes pingdom.ProbeAPI, regions ...pingdom.Region) (*List, error) {
+	list, err := probes.List(map[string]string{"onlyactive": "true"})
+	if err != nil {
+		return nil, err
+	}
+	return FromProbes(list, regions...), nil
+}
+
+// New returns the sorted set of the given prefixes.
+func New(prefixes ...netip.Prefix) *List {
+	seen := map[netip.Prefix]bool{}
+	l := &List{}
+	for _, p := range prefixes {
+		p = p.Masked()
+		if !p.IsValid() || seen[p] {
+			continue
+		}
+		seen[p] = true
+		if p.Addr().Is4() {
+			l.IPv4 = append(l.IPv4, p)
+		} else {
+			l.IPv6 = append(l.IPv6, p)
+		}
+	}
+	sortPrefixes(l.IPv4)
+	sortPrefixes(l.IPv6)
+	return l
+}
+
+// Parse reads the prefixes of a list written in any Format.  Every word
+// that is an IP address or prefix is read, so comments and the surrounding
+// syntax of each format are ignored.
+func Parse(r io.Reader) (*List, error) {
+	var prefixes []netip.Prefix
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		words := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ',' || r == ';' || r == '{' || r == '}'
+		})
+		for _, word := range words {
+			if p, err := netip.ParsePrefix(word); err == nil {
+				prefixes = append(prefixes, p)
+			} else if addr, err := netip.ParseAddr(word); err == nil {
+				prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return New(prefixes...), nil
+}
+
+// Prefixes returns the IPv4 prefixes followed by the IPv6 prefixes.
+func (l *List) Prefixes() []netip.Prefix {
+	return append(append([]netip.Prefix{}, l.IPv4...), l.IPv6...)
+}
+
+// Contains reports whether addr is in one of the prefixes.
+func (l *List) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range l.Prefixes() {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write renders the list in the given format.
+func (l *List) Write(w io.Writer, format Format) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case CIDR:
+		for _, p := range l.Prefixes() {
+			fmt.Fprintln(bw, p)
+		}
+	case Nginx:
+		fmt.Fprintln(bw, "# Pingdom probes")
+		for _, p := range l.Prefixes() {
+			fmt.Fprintf(bw, "allow %s;\n", p)
+		}
+	case IPTables:
+		for _, p := range l.IPv4 {
+			fmt.Fprintf(bw, "iptables -A pingdom-probes -s %s -j ACCEPT\n", p)
+		}
+		for _, p := range l.IPv6 {
+			fmt.Fprintf(bw, "ip6tables -A pingdom-probes -s %s -j ACCEPT\n", p)
+		}
+	case NFTables:
+		fmt.Fprintln(bw, "table inet pingdom {")
+		writeSet(bw, "probes4", "ipv4_addr", l.IPv4)
+		writeSet(bw, "probes6", "ipv6_addr", l.IPv6)
+		fmt.Fprintln(bw, "}")
+	default:
+		return fmt.Errorf("allowlist: unknown format %d", format)
+	}
+	return bw.Flush()
+}
+
+func writeSet(w io.Writer, name, typ string, prefixes []netip.Prefix) {
+	fmt.Fprintf(w, "\tset %s {\n\t\ttype %s\n\t\tflags interval\n", name, typ)
+	if len(prefixes) > 0 {
+		elements := make([]string, len(prefixes))
+		for i, p := range prefixes {
+			elements[i] = p.String()
+		}
+		fmt.Fprintf(w, "\t\telements = { %s }\n", strings.Join(elements, ", "))
+	}
+	fmt.Fprintln(w, "\t}")
+}
+
+// Diff is the difference between two lists.
+type Diff struct {
+	Added   []netip.Prefix
+	Removed []netip.Prefix
+}
+
+// Compare returns the prefixes added to and removed from previous in
+// current.
+func Compare(previous, current *List) Diff {
+	old := map[netip.Prefix]bool{}
+	for _, p := range previous.Prefixes() {
+		old[p] = true
+	}
+	d := Diff{}
+	for _, p := range current.Prefixes() {
+		if !old[p] {
+			d.Added = append(d.Added, p)
+		}
+		delete(old, p)
+	}
+	for _, p := range previous.Prefixes() {
+		if old[p] {
+			d.Removed = append(d.Removed, p)
+		}
+	}
+	return d
+}
+
+// Empty reports whether the lists are the same.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// String returns the diff with a line per prefix, prefixed by "+" if it was
+// added and "-" if it was removed.
+func (d Diff) String() string {
+	var b strings.Builder
+	for _, p := range d.Added {
+		fmt.Fprintf(&b, "+%s\n", p)
+	}
+	for _, p := range d.Removed {
+		fmt.Fprintf(&b, "-%s\n", p)
+	}
+	return b.String()
+}
+
+func sortPrefixes(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		if c := prefixes[i].Addr().Compare(prefixes[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+}
@@ -0,0 +1,120 @@
+package allowlist
+
+import (
+	"bytes"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/stretchr/testify/assert"
+)
+
+var probes = []pingdom.ProbeResponse{
+	{ID: 1, Name: "Stockholm", Active: true, IP: "10.0.0.2", IPv6: "2001:db8::2", Region: "EU"},
+	{ID: 2, Name: "Dallas", Active: true, IP: "10.0.0.1", IPv6: "", Region: "NA"},
+	{ID: 3, Name: "Old", Active: false, IP: "10.0.0.3", Region: "EU"},
+	{ID: 4, Name: "Sydney", Active: true, IP: "10.0.0.4", IPv6: "2001:db8::4", Region: "APAC"},
+	{ID: 5, Name: "Duplicate", Active: true, IP: "10.0.0.1", Region: "NA"},
+	{ID: 6, Name: "Broken", Active: true, IP: "not an ip", Region: "NA"},
+}
+
+func prefixes(ss ...string) []netip.Prefix {
+	var ps []netip.Prefix
+	for _, s := range ss {
+		ps = append(ps, netip.MustParsePrefix(s))
+	}
+	return ps
+}
+
+func TestFromProbes(t *testing.T) {
+	l := FromProbes(probes)
+	assert.Equal(t, prefixes("10.0.0.1/32", "10.0.0.2/32", "10.0.0.4/32"), l.IPv4)
+	assert.Equal(t, prefixes("2001:db8::2/128", "2001:db8::4/128"), l.IPv6)
+
+	l = FromProbes(probes, pingdom.RegionEU, pingdom.RegionNA)
+	assert.Equal(t, prefixes("10.0.0.1/32", "10.0.0.2/32"), l.IPv4)
+	assert.Equal(t, prefixes("2001:db8::2/128"), l.IPv6)
+
+	assert.True(t, l.Contains(netip.MustParseAddr("10.0.0.1")))
+	assert.True(t, l.Contains(netip.MustParseAddr("::ffff:10.0.0.1")))
+	assert.False(t, l.Contains(netip.MustParseAddr("10.0.0.4")))
+}
+
+func TestFetch(t *testing.T) {
+	var got []map[string]string
+	mock := &pingdommock.ProbeAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.ProbeResponse, error) {
+			got = params
+			return probes, nil
+		},
+	}
+
+	l, err := Fetch(mock, pingdom.RegionAPAC)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"onlyactive": "true"}}, got)
+	assert.Equal(t, prefixes("10.0.0.4/32", "2001:db8::4/128"), l.Prefixes())
+}
+
+func write(t *testing.T, l *List, format Format) string {
+	var buf bytes.Buffer
+	assert.NoError(t, l.Write(&buf, format))
+	return buf.String()
+}
+
+func TestWrite(t *testing.T) {
+	l := FromProbes(probes, pingdom.RegionEU, pingdom.RegionNA)
+
+	assert.Equal(t, "10.0.0.1/32\n10.0.0.2/32\n2001:db8::2/128\n", write(t, l, CIDR))
+	assert.Equal(t, "# Pingdom probes\nallow 10.0.0.1/32;\nallow 10.0.0.2/32;\nallow 2001:db8::2/128;\n", write(t, l, Nginx))
+	assert.Equal(t, "iptables -A pingdom-probes -s 10.0.0.1/32 -j ACCEPT\n"+
+		"iptables -A pingdom-probes -s 10.0.0.2/32 -j ACCEPT\n"+
+		"ip6tables -A pingdom-probes -s 2001:db8::2/128 -j ACCEPT\n", write(t, l, IPTables))
+	assert.Equal(t, `table inet pingdom {
+	set probes4 {
+		type ipv4_addr
+		flags interval
+		elements = { 10.0.0.1/32, 10.0.0.2/32 }
+	}
+	set probes6 {
+		type ipv6_addr
+		flags interval
+		elements = { 2001:db8::2/128 }
+	}
+}
+`, write(t, l, NFTables))
+
+	assert.EqualError(t, l.Write(&bytes.Buffer{}, Format(9)), "allowlist: unknown format 9")
+}
+
+func TestWrite_EmptySet(t *testing.T) {
+	l := New(prefixes("10.0.0.1/32")...)
+	assert.NotContains(t, write(t, l, NFTables), "elements = {  }")
+}
+
+func TestParse(t *testing.T) {
+	l := FromProbes(probes)
+	for _, format := range []Format{CIDR, Nginx, IPTables, NFTables} {
+		parsed, err := Parse(strings.NewReader(write(t, l, format)))
+		assert.NoError(t, err)
+		assert.Equal(t, l, parsed, "format %d", format)
+	}
+
+	parsed, err := Parse(strings.NewReader("# comment 10.9.9.9\n10.0.0.0/8 192.168.1.1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, prefixes("10.0.0.0/8", "192.168.1.1/32"), parsed.IPv4)
+}
+
+func TestCompare(t *testing.T) {
+	previous := New(prefixes("10.0.0.1/32", "10.0.0.3/32", "2001:db8::2/128")...)
+	current := FromProbes(probes, pingdom.RegionEU, pingdom.RegionNA)
+
+	d := Compare(previous, current)
+	assert.False(t, d.Empty())
+	assert.Equal(t, prefixes("10.0.0.2/32"), d.Added)
+	assert.Equal(t, prefixes("10.0.0.3/32"), d.Removed)
+	assert.Equal(t, "+10.0.0.2/32\n-10.0.0.3/32\n", d.String())
+
+	assert.True(t, Compare(current, current).Empty())
+}
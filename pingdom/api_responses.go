@@ -107,6 +107,47 @@ type TeamResponse struct {
 	Members []TeamMemberResponse `json:"members,omitempty"`
 }
 
+// ExpandedCheck is a check together with the contacts and teams it alerts,
+// as returned by CheckService.ReadExpanded.
+type ExpandedCheck struct {
+	CheckResponse
+
+	// Contacts are the contacts in UserIds, in the same order.
+	Contacts []Contact `json:"contacts"`
+
+	// ExpandedTeams are the teams in TeamIds, in the same order.  The
+	// embedded Teams field only holds their IDs and names.
+	ExpandedTeams []ExpandedTeam `json:"expanded_teams"`
+}
+
+// ExpandedTeam is a team together with its member contacts.
+type ExpandedTeam struct {
+	TeamResponse
+
+	// Contacts are the members of the team, in the same order as Members.
+	Contacts []Contact `json:"contacts"`
+}
+
+// Recipients returns the contacts alerted by the check, directly or through
+// a team.  Contacts in several teams are only returned once.
+func (e *ExpandedCheck) Recipients() []Contact {
+	seen := map[int]bool{}
+	var recipients []Contact
+	add := func(contacts []Contact) {
+		for _, c := range contacts {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				recipients = append(recipients, c)
+			}
+		}
+	}
+	add(e.Contacts)
+	for _, t := range e.ExpandedTeams {
+		add(t.Contacts)
+	}
+	return recipients
+}
+
 // TeamMemberResponse represents the JSON response for contacts in alerting teams from the Pingdom API.
 type TeamMemberResponse struct {
 	ID   int    `json:"id"`
@@ -191,6 +232,19 @@ func (c *CheckResponseType) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON converts a CheckResponseType into the same shapes accepted by
+// UnmarshalJSON: an object keyed by the type name when details are present,
+// otherwise the bare type name.
+func (c CheckResponseType) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.HTTP != nil:
+		return json.Marshal(map[string]*CheckResponseHTTPDetails{"http": c.HTTP})
+	case c.TCP != nil:
+		return json.Marshal(map[string]*CheckResponseTCPDetails{"tcp": c.TCP})
+	}
+	return json.Marshal(c.Name)
+}
+
 // CheckResponseHTTPDetails represents the details specific to HTTP checks.
 type CheckResponseHTTPDetails struct {
 	Url               string            `json:"url,omitempty"`
@@ -52,6 +52,24 @@ func TestCheckResponseUnmarshal(t *testing.T) {
 	assert.Equal(t, "HIGH", ck.SeverityLevel)
 }
 
+func TestCheckResponseTypeMarshal(t *testing.T) {
+	var ck CheckResponse
+	err := json.Unmarshal([]byte(detailedCheckJSON), &ck)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(ck)
+	assert.NoError(t, err)
+
+	var roundTrip CheckResponse
+	err = json.Unmarshal(b, &roundTrip)
+	assert.NoError(t, err)
+	assert.Equal(t, ck.Type, roundTrip.Type)
+
+	b, err = json.Marshal(CheckResponseType{Name: "ping"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"ping"`, string(b))
+}
+
 var detailedContactJSON = `
 {
 	"contacts": [
@@ -137,3 +155,35 @@ func TestCheckContactUnmarshal(t *testing.T) {
 	assert.NotNil(t, contact.ID)
 	assert.Equal(t, expectedNotificationTargets, contact.NotificationTargets)
 }
+
+func TestExpandedCheckMarshal(t *testing.T) {
+	check := ExpandedCheck{
+		CheckResponse: CheckResponse{
+			ID:    85975,
+			Teams: []CheckTeamResponse{{ID: 1, Name: "Oncall"}},
+		},
+		ExpandedTeams: []ExpandedTeam{{
+			TeamResponse: TeamResponse{ID: 1, Name: "Oncall"},
+			Contacts:     []Contact{{Name: "John Doe"}},
+		}},
+	}
+
+	b, err := json.Marshal(check)
+	assert.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(b, &fields))
+	assert.Contains(t, fields, "teams")
+	assert.Contains(t, fields, "expanded_teams")
+	assert.Contains(t, fields, "contacts")
+	assert.NotContains(t, fields, "Teams")
+	assert.NotContains(t, fields, "Contacts")
+	assert.JSONEq(t, `[{"id": 1, "name": "Oncall"}]`, string(fields["teams"]))
+
+	var decoded ExpandedCheck
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, check.Teams, decoded.Teams)
+	if assert.Len(t, decoded.ExpandedTeams, 1) {
+		assert.Equal(t, "John Doe", decoded.ExpandedTeams[0].Contacts[0].Name)
+	}
+}
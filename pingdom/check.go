@@ -19,6 +19,25 @@ type Check interface {
 	Valid() error
 }
 
+// CheckAPI is the set of operations provided by CheckService.  Code that
+// accepts a CheckAPI rather than a *CheckService can be tested with a mock.
+type CheckAPI interface {
+	List(params ...map[string]string) ([]CheckResponse, error)
+	Create(check Check) (*CheckResponse, error)
+	Read(id int) (*CheckResponse, error)
+	ReadExpanded(id int) (*ExpandedCheck, error)
+	Update(id int, check Check) (*PingdomResponse, error)
+	Delete(id int) (*PingdomResponse, error)
+	SummaryPerformance(request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error)
+	Results(id int, params ...map[string]string) (*ResultsResponse, error)
+	ListByTag(tags ...string) ([]CheckResponse, error)
+	AddTag(tag string, ids ...int) error
+	RemoveTag(tag string, ids ...int) error
+	RenameTag(from, to string) ([]int, error)
+}
+
+var _ CheckAPI = (*CheckService)(nil)
+
 // List returns a list of checks from Pingdom.
 // This returns type CheckResponse rather than Check since the
 // Pingdom API does not return a complete representation of a check.
@@ -32,7 +51,7 @@ func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, erro
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +114,65 @@ func (cs *CheckService) Read(id int) (*CheckResponse, error) {
 	return m.Check, err
 }
 
+// ReadExpanded returns detailed information about a check along with the
+// contacts and teams it alerts, including their notification targets.  The
+// contacts and teams are fetched with a single call to ContactService.List
+// and TeamService.List rather than one call each.  Contacts and teams that
+// no longer exist are left out.
+func (cs *CheckService) ReadExpanded(id int) (*ExpandedCheck, error) {
+	check, err := cs.Read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := &ExpandedCheck{CheckResponse: *check}
+	if len(check.UserIds) == 0 && len(check.TeamIds) == 0 {
+		return expanded, nil
+	}
+
+	contacts, err := cs.client.Contacts.List()
+	if err != nil {
+		return nil, err
+	}
+	contactsByID := make(map[int]Contact, len(contacts))
+	for _, c := range contacts {
+		contactsByID[c.ID] = c
+	}
+	resolve := func(ids []int) []Contact {
+		resolved := []Contact{}
+		for _, id := range ids {
+			if c, ok := contactsByID[id]; ok {
+				resolved = append(resolved, c)
+			}
+		}
+		return resolved
+	}
+	expanded.Contacts = resolve(check.UserIds)
+
+	if len(check.TeamIds) == 0 {
+		return expanded, nil
+	}
+	teams, err := cs.client.Teams.List()
+	if err != nil {
+		return nil, err
+	}
+	teamsByID := make(map[int]TeamResponse, len(teams))
+	for _, t := range teams {
+		teamsByID[t.ID] = t
+	}
+	for _, teamID := range check.TeamIds {
+		t, ok := teamsByID[teamID]
+		if !ok {
+			continue
+		}
+		expanded.ExpandedTeams = append(expanded.ExpandedTeams, ExpandedTeam{
+			TeamResponse: t,
+			Contacts:     resolve(t.Team().MemberIDs),
+		})
+	}
+	return expanded, nil
+}
+
 // Update will update the check represented by the given ID with the values
 // in the given check.  You should submit the complete list of values in
 // the given check parameter, not just those that have changed.
@@ -161,7 +239,7 @@ func (cs *CheckService) Results(id int, params ...map[string]string) (*ResultsRe
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -178,3 +256,109 @@ func (cs *CheckService) Results(id int, params ...map[string]string) (*ResultsRe
 
 	return m, err
 }
+
+// ListByTag returns the checks that have any of the given tags.  The tags of
+// each check are included in the response.
+func (cs *CheckService) ListByTag(tags ...string) ([]CheckResponse, error) {
+	return cs.List(map[string]string{
+		"tags":         NewTags(tags...).String(),
+		"include_tags": "true",
+	})
+}
+
+// AddTag adds a tag to the checks with the given IDs.  Other tags and
+// settings of the checks are left unchanged.
+func (cs *CheckService) AddTag(tag string, ids ...int) error {
+	if err := validTag(tag); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := cs.putTags(id, map[string]string{"addtags": tag}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveTag removes a tag from the checks with the given IDs.  Checks
+// without the tag are not modified.  The API cannot remove a single tag, so
+// the tags of each check are read and written back; see modifyTags for what
+// happens when they change in between.
+func (cs *CheckService) RemoveTag(tag string, ids ...int) error {
+	for _, id := range ids {
+		if _, err := cs.modifyTags(id, func(tags Tags) Tags { return tags.Remove(tag) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenameTag replaces a tag with another on every check of the account.  It
+// returns the IDs of the checks that were modified.  Like RemoveTag, it reads
+// and writes back the tags of each check.
+func (cs *CheckService) RenameTag(from, to string) ([]int, error) {
+	if err := validTag(to); err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, nil
+	}
+
+	checks, err := cs.ListByTag(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var renamed []int
+	for _, check := range checks {
+		if !check.TagSet().Has(from) {
+			continue
+		}
+		modified, err := cs.modifyTags(check.ID, func(tags Tags) Tags {
+			if !tags.Has(from) {
+				return tags
+			}
+			return tags.Remove(from).Add(to)
+		})
+		if err != nil {
+			return renamed, err
+		}
+		if modified {
+			renamed = append(renamed, check.ID)
+		}
+	}
+	return renamed, nil
+}
+
+// modifyTags reads the current tags of a check and, if fn changes them,
+// writes them back.  Only the tags are sent so that other settings changed
+// since the check was read are not overwritten.  The tags themselves are
+// replaced: the API has no conditional updates, so the last writer wins and
+// tags added by someone else between the read and the update are lost.
+func (cs *CheckService) modifyTags(id int, fn func(Tags) Tags) (bool, error) {
+	check, err := cs.Read(id)
+	if err != nil {
+		return false, err
+	}
+
+	current := check.TagSet()
+	updated := fn(current)
+	if updated.Equal(current) {
+		return false, nil
+	}
+	if err := updated.Valid(); err != nil {
+		return false, err
+	}
+	return true, cs.putTags(id, map[string]string{"tags": updated.String()})
+}
+
+func (cs *CheckService) putTags(id int, params map[string]string) error {
+	req, err := cs.client.NewRequest("PUT", "/checks/"+strconv.Itoa(id), params)
+	if err != nil {
+		return err
+	}
+
+	_, err = cs.client.Do(req, &PingdomResponse{})
+	return err
+}
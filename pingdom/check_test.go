@@ -3,6 +3,7 @@ package pingdom
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -458,3 +459,176 @@ func TestCheckServiceResults(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, results)
 }
+
+func TestCheckServiceListByTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "nginx,web", r.URL.Query().Get("tags"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		fmt.Fprint(w, `{"checks": [{"id": 85975, "name": "My check 1", "tags": [{"name": "web", "type": "u", "count": 2}]}]}`)
+	})
+
+	checks, err := client.Checks.ListByTag("web", "nginx")
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, Tags{"web"}, checks[0].TagSet())
+}
+
+func TestCheckServiceAddTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var updated []string
+	for _, id := range []string{"1", "2"} {
+		id := id
+		mux.HandleFunc("/checks/"+id, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "PUT")
+			assert.Equal(t, url.Values{"addtags": {"web"}}, r.URL.Query())
+			updated = append(updated, id)
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+		})
+	}
+
+	assert.NoError(t, client.Checks.AddTag("web", 1, 2))
+	assert.Equal(t, []string{"1", "2"}, updated)
+
+	assert.Error(t, client.Checks.AddTag("a,b", 1))
+}
+
+func TestCheckServiceRemoveTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var puts []url.Values
+	checks := map[string]string{
+		"1": `{"check": {"id": 1, "tags": [{"name": "web", "type": "u"}, {"name": "old", "type": "u"}]}}`,
+		"2": `{"check": {"id": 2, "tags": [{"name": "web", "type": "u"}]}}`,
+	}
+	for id, body := range checks {
+		body := body
+		mux.HandleFunc("/checks/"+id, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				fmt.Fprint(w, body)
+			case "PUT":
+				puts = append(puts, r.URL.Query())
+				fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+			}
+		})
+	}
+
+	assert.NoError(t, client.Checks.RemoveTag("old", 1, 2))
+	assert.Equal(t, []url.Values{{"tags": {"web"}}}, puts)
+}
+
+func TestCheckServiceRenameTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "old", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"checks": [
+			{"id": 1, "tags": [{"name": "old", "type": "u"}, {"name": "web", "type": "u"}]},
+			{"id": 2, "tags": [{"name": "older", "type": "u"}]}
+		]}`)
+	})
+	var puts []url.Values
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"check": {"id": 1, "tags": [{"name": "old", "type": "u"}, {"name": "web", "type": "u"}]}}`)
+		case "PUT":
+			puts = append(puts, r.URL.Query())
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+		}
+	})
+
+	renamed, err := client.Checks.RenameTag("old", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, renamed)
+	assert.Equal(t, []url.Values{{"tags": {"new,web"}}}, puts)
+
+	renamed, err = client.Checks.RenameTag("old", "old")
+	assert.NoError(t, err)
+	assert.Empty(t, renamed)
+
+	_, err = client.Checks.RenameTag("old", "")
+	assert.Error(t, err)
+}
+
+func TestCheckServiceReadExpanded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	contactsRequests, teamsRequests := 0, 0
+	mux.HandleFunc("/checks/85975", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"check": {
+			"id": 85975,
+			"name": "My check 7",
+			"userids": [3, 1, 99],
+			"teams": [{"id": 20, "name": "Oncall"}, {"id": 98, "name": "Deleted"}, {"id": 21, "name": "Managers"}]
+		}}`)
+	})
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		contactsRequests++
+		fmt.Fprint(w, `{"contacts": [
+			{"id": 1, "name": "One", "notification_targets": {"email": [{"severity": "HIGH", "address": "one@example.com"}]}},
+			{"id": 2, "name": "Two", "notification_targets": {"sms": [{"severity": "HIGH", "country_code": "1", "number": "5555555555", "provider": "nexmo"}]}},
+			{"id": 3, "name": "Three"}
+		]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		teamsRequests++
+		fmt.Fprint(w, `{"teams": [
+			{"id": 20, "name": "Oncall", "members": [{"id": 2, "name": "Two", "type": "contact"}, {"id": 1, "name": "One", "type": "contact"}]},
+			{"id": 21, "name": "Managers", "members": [{"id": 2, "name": "Two", "type": "contact"}]},
+			{"id": 22, "name": "Other"}
+		]}`)
+	})
+
+	check, err := client.Checks.ReadExpanded(85975)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, contactsRequests)
+	assert.Equal(t, 1, teamsRequests)
+
+	assert.Equal(t, "My check 7", check.Name)
+	assert.Equal(t, []int{20, 98, 21}, check.TeamIds)
+
+	names := func(contacts []Contact) []string {
+		var n []string
+		for _, c := range contacts {
+			n = append(n, c.Name)
+		}
+		return n
+	}
+	assert.Equal(t, []string{"Three", "One"}, names(check.Contacts))
+	if assert.Len(t, check.ExpandedTeams, 2) {
+		assert.Equal(t, "Oncall", check.ExpandedTeams[0].Name)
+		assert.Equal(t, []string{"Two", "One"}, names(check.ExpandedTeams[0].Contacts))
+		assert.Equal(t, "5555555555", check.ExpandedTeams[0].Contacts[0].NotificationTargets.SMS[0].Number)
+		assert.Equal(t, "Managers", check.ExpandedTeams[1].Name)
+	}
+	assert.Equal(t, []string{"Three", "One", "Two"}, names(check.Recipients()))
+}
+
+func TestCheckServiceReadExpanded_NoRecipients(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/85975", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"check": {"id": 85975, "name": "My check 7"}}`)
+	})
+
+	check, err := client.Checks.ReadExpanded(85975)
+	assert.NoError(t, err)
+	assert.Empty(t, check.Contacts)
+	assert.Empty(t, check.ExpandedTeams)
+	assert.Empty(t, check.Recipients())
+}
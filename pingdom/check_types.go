@@ -96,7 +96,7 @@ func (ck *HttpCheck) PutParams() map[string]string {
 		"postdata":         ck.PostData,
 		"integrationids":   intListToCDString(ck.IntegrationIds),
 		"tags":             ck.Tags,
-		"probe_filters":    ck.ProbeFilters,
+		"probe_filters":    normalizeProbeFilters(ck.ProbeFilters),
 		"userids":          intListToCDString(ck.UserIds),
 		"teamids":          intListToCDString(ck.TeamIds),
 	}
@@ -184,7 +184,7 @@ func (ck *HttpCheck) Valid() error {
 		return fmt.Errorf("`ShouldContain` and `ShouldNotContain` must not be declared at the same time")
 	}
 
-	return nil
+	return validProbeFilters(ck.ProbeFilters)
 }
 
 // PutParams returns a map of parameters for a PingCheck that can be sent along
@@ -198,7 +198,8 @@ func (ck *PingCheck) PutParams() map[string]string {
 		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
 		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
 		"integrationids":   intListToCDString(ck.IntegrationIds),
-		"probe_filters":    ck.ProbeFilters,
+		"tags":             ck.Tags,
+		"probe_filters":    normalizeProbeFilters(ck.ProbeFilters),
 		"userids":          intListToCDString(ck.UserIds),
 		"teamids":          intListToCDString(ck.TeamIds),
 	}
@@ -244,7 +245,7 @@ func (ck *PingCheck) Valid() error {
 		ck.Resolution != 30 && ck.Resolution != 60 {
 		return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", ck.Resolution)
 	}
-	return nil
+	return validProbeFilters(ck.ProbeFilters)
 }
 
 // PutParams returns a map of parameters for a TCPCheck that can be sent along
@@ -258,7 +259,7 @@ func (ck *TCPCheck) PutParams() map[string]string {
 		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
 		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
 		"integrationids":   intListToCDString(ck.IntegrationIds),
-		"probe_filters":    ck.ProbeFilters,
+		"probe_filters":    normalizeProbeFilters(ck.ProbeFilters),
 		"tags":             ck.Tags,
 		"userids":          intListToCDString(ck.UserIds),
 		"teamids":          intListToCDString(ck.TeamIds),
@@ -315,7 +316,7 @@ func (ck *TCPCheck) Valid() error {
 		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1")
 	}
 
-	return nil
+	return validProbeFilters(ck.ProbeFilters)
 }
 
 func intListToCDString(integers []int) string {
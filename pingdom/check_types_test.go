@@ -213,6 +213,7 @@ func TestPingCheckPutParams(t *testing.T) {
 		"notifyagainevery": "0",
 		"notifywhenbackup": "false",
 		"integrationids":   "33333333,44444444",
+		"tags":             "",
 		"probe_filters":    "",
 		"userids":          "123,456",
 		"teamids":          "789",
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 // ContactService provides an interface to Pingdom contacts.
@@ -18,6 +20,25 @@ type ContactAPI interface {
 	ValidContact() error
 }
 
+// ContactServiceAPI is the set of operations provided by ContactService.
+// Code that accepts a ContactServiceAPI rather than a *ContactService can be
+// tested with a mock.
+type ContactServiceAPI interface {
+	List() ([]Contact, error)
+	Read(contactID int) (*Contact, error)
+	Create(contact ContactAPI) (*Contact, error)
+	Update(id int, contact ContactAPI) (*PingdomResponse, error)
+	Delete(id int) (*PingdomResponse, error)
+	AddSMSTarget(id int, target SMSNotification) error
+	RemoveSMSTarget(id int, target SMSNotification) error
+	ReplaceSMSTarget(id int, old, target SMSNotification) error
+	AddEmailTarget(id int, target EmailNotification) error
+	RemoveEmailTarget(id int, target EmailNotification) error
+	ReplaceEmailTarget(id int, old, target EmailNotification) error
+}
+
+var _ ContactServiceAPI = (*ContactService)(nil)
+
 // List returns a list of all contacts and their contact details.
 func (cs *ContactService) List() ([]Contact, error) {
 
@@ -26,7 +47,7 @@ func (cs *ContactService) List() ([]Contact, error) {
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -75,13 +96,14 @@ func (cs *ContactService) Create(contact ContactAPI) (*Contact, error) {
 	m := &createContactJSONResponse{}
 	_, err = cs.client.Do(req, m)
 	if err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 	return m.Contact, err
 }
 
-// Update a contact's core properties not contact targets.
+// Update replaces a contact's properties, including all of its notification
+// targets.  Use AddSMSTarget, AddEmailTarget and related methods to change a
+// single target.
 func (cs *ContactService) Update(id int, contact ContactAPI) (*PingdomResponse, error) {
 	if err := contact.ValidContact(); err != nil {
 		return nil, err
@@ -114,3 +136,171 @@ func (cs *ContactService) Delete(id int) (*PingdomResponse, error) {
 	}
 	return m, err
 }
+
+// AddSMSTarget adds an SMS target to a contact, leaving its other targets
+// unchanged.  It returns ErrTargetExists if the contact already has a target
+// with the same phone number.
+func (cs *ContactService) AddSMSTarget(id int, target SMSNotification) error {
+	if err := (NotificationTargets{SMS: []SMSNotification{target}}).Valid(); err != nil {
+		return err
+	}
+
+	return cs.modifyTargets(id, func(targets *NotificationTargets) error {
+		if i := indexSMS(targets.SMS, target); i >= 0 {
+			return fmt.Errorf("%w: SMS %s", ErrTargetExists, smsString(target))
+		}
+		targets.SMS = append(targets.SMS, target.Normalized())
+		return nil
+	})
+}
+
+// RemoveSMSTarget removes the SMS target with the phone number of target from
+// a contact.  It returns ErrTargetNotFound if the contact has no such target.
+func (cs *ContactService) RemoveSMSTarget(id int, target SMSNotification) error {
+	return cs.modifyTargets(id, func(targets *NotificationTargets) error {
+		i := indexSMS(targets.SMS, target)
+		if i < 0 {
+			return fmt.Errorf("%w: SMS %s", ErrTargetNotFound, smsString(target))
+		}
+		targets.SMS = append(targets.SMS[:i], targets.SMS[i+1:]...)
+		return nil
+	})
+}
+
+// ReplaceSMSTarget replaces the SMS target with the phone number of old by
+// target, keeping its position among the targets of the contact.  It returns
+// ErrTargetNotFound if the contact has no such target and ErrTargetExists if
+// the new phone number is already used by another target.
+func (cs *ContactService) ReplaceSMSTarget(id int, old, target SMSNotification) error {
+	if err := (NotificationTargets{SMS: []SMSNotification{target}}).Valid(); err != nil {
+		return err
+	}
+
+	return cs.modifyTargets(id, func(targets *NotificationTargets) error {
+		i := indexSMS(targets.SMS, old)
+		if i < 0 {
+			return fmt.Errorf("%w: SMS %s", ErrTargetNotFound, smsString(old))
+		}
+		if j := indexSMS(targets.SMS, target); j >= 0 && j != i {
+			return fmt.Errorf("%w: SMS %s", ErrTargetExists, smsString(target))
+		}
+		targets.SMS[i] = target.Normalized()
+		return nil
+	})
+}
+
+// AddEmailTarget adds an email target to a contact, leaving its other targets
+// unchanged.  It returns ErrTargetExists if the contact already has a target
+// with the same address.
+func (cs *ContactService) AddEmailTarget(id int, target EmailNotification) error {
+	if err := (NotificationTargets{Email: []EmailNotification{target}}).Valid(); err != nil {
+		return err
+	}
+
+	return cs.modifyTargets(id, func(targets *NotificationTargets) error {
+		if i := indexEmail(targets.Email, target); i >= 0 {
+			return fmt.Errorf("%w: email %s", ErrTargetExists, target.Address)
+		}
+		targets.Email = append(targets.Email, target.Normalized())
+		return nil
+	})
+}
+
+// RemoveEmailTarget removes the email target with the address of target from
+// a contact.  It returns ErrTargetNotFound if the contact has no such target.
+func (cs *ContactService) RemoveEmailTarget(id int, target EmailNotification) error {
+	return cs.modifyTargets(id, func(targets *NotificationTargets) error {
+		i := indexEmail(targets.Email, target)
+		if i < 0 {
+			return fmt.Errorf("%w: email %s", ErrTargetNotFound, target.Address)
+		}
+		targets.Email = append(targets.Email[:i], targets.Email[i+1:]...)
+		return nil
+	})
+}
+
+// ReplaceEmailTarget replaces the email target with the address of old by
+// target, keeping its position among the targets of the contact.  It returns
+// ErrTargetNotFound if the contact has no such target and ErrTargetExists if
+// the new address is already used by another target.
+func (cs *ContactService) ReplaceEmailTarget(id int, old, target EmailNotification) error {
+	if err := (NotificationTargets{Email: []EmailNotification{target}}).Valid(); err != nil {
+		return err
+	}
+
+	return cs.modifyTargets(id, func(targets *NotificationTargets) error {
+		i := indexEmail(targets.Email, old)
+		if i < 0 {
+			return fmt.Errorf("%w: email %s", ErrTargetNotFound, old.Address)
+		}
+		if j := indexEmail(targets.Email, target); j >= 0 && j != i {
+			return fmt.Errorf("%w: email %s", ErrTargetExists, target.Address)
+		}
+		targets.Email[i] = target.Normalized()
+		return nil
+	})
+}
+
+// modifyTargets reads a contact, applies fn to its normalized targets and
+// writes them back along with the name and paused state that were read.  As
+// the API has no conditional updates, the contact is read again just before
+// writing and ErrContactModified is returned if it changed in the meantime.
+// This is a best-effort check: a change made between the second read and the
+// update is still overwritten.
+func (cs *ContactService) modifyTargets(id int, fn func(*NotificationTargets) error) error {
+	contact, err := cs.Read(id)
+	if err != nil {
+		return err
+	}
+
+	current := contact.NotificationTargets.Normalized()
+	targets := contact.NotificationTargets.Normalized()
+	if err := fn(&targets); err != nil {
+		return err
+	}
+
+	latest, err := cs.Read(id)
+	if err != nil {
+		return err
+	}
+	if latest.Name != contact.Name || latest.Paused != contact.Paused ||
+		!reflect.DeepEqual(latest.NotificationTargets.Normalized(), current) {
+		return fmt.Errorf("%w: contact %d", ErrContactModified, id)
+	}
+
+	_, err = cs.Update(id, &Contact{
+		Name:                contact.Name,
+		Paused:              contact.Paused,
+		NotificationTargets: targets,
+	})
+	return err
+}
+
+// indexSMS returns the index of the target with the phone number of target,
+// or -1.
+func indexSMS(targets []SMSNotification, target SMSNotification) int {
+	cc, number := NormalizePhoneNumber(target.CountryCode, target.Number)
+	for i, t := range targets {
+		if c, n := NormalizePhoneNumber(t.CountryCode, t.Number); c == cc && n == number {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexEmail returns the index of the target with the address of target, or
+// -1.  Addresses are compared case-insensitively.
+func indexEmail(targets []EmailNotification, target EmailNotification) int {
+	address := strings.TrimSpace(target.Address)
+	for i, t := range targets {
+		if strings.EqualFold(strings.TrimSpace(t.Address), address) {
+			return i
+		}
+	}
+	return -1
+}
+
+func smsString(target SMSNotification) string {
+	cc, number := NormalizePhoneNumber(target.CountryCode, target.Number)
+	return "+" + cc + " " + number
+}
@@ -0,0 +1,15 @@
+package pingdom
+
+import "errors"
+
+// ErrTargetExists is an error for when a notification target being added is
+// already set on the contact.
+var ErrTargetExists = errors.New("notification target already exists")
+
+// ErrTargetNotFound is an error for when a notification target being removed
+// or replaced is not set on the contact.
+var ErrTargetNotFound = errors.New("notification target not found")
+
+// ErrContactModified is an error for when a contact changed between being
+// read and being updated.
+var ErrContactModified = errors.New("contact was modified concurrently")
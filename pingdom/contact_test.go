@@ -1,6 +1,8 @@
 package pingdom
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -279,3 +281,201 @@ func TestContactService_Update(t *testing.T) {
 	assert.Equal(t, want, response, "Contacts.Update() should return PingdomResponse with message")
 
 }
+
+const targetsContactJSON = `{
+	"contact": {
+		"id": 12941,
+		"name": "John Doe",
+		"paused": true,
+		"type": "user",
+		"notification_targets": {
+			"email": [
+				{"severity": "HIGH", "address": "johndoe@teamrocket.com"}
+			],
+			"sms": [
+				{"severity": "HIGH", "country_code": "1", "number": "5555555555", "provider": "nexmo"},
+				{"severity": "LOW", "country_code": "46", "number": "701234567", "provider": "nexmo"}
+			]
+		}
+	}
+}`
+
+// handleTargetsContact serves the contact in targetsContactJSON, or the JSON
+// returned by changed on reads after the first, decodes PUT bodies into put
+// and returns the number of times the contact was read.
+func handleTargetsContact(t *testing.T, put *map[string]interface{}, changed func() string) *int {
+	reads := 0
+	mux.HandleFunc("/alerting/contacts/12941", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			reads++
+			if reads > 1 && changed != nil {
+				fmt.Fprint(w, changed())
+				return
+			}
+			fmt.Fprint(w, targetsContactJSON)
+		case "PUT":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(put))
+			fmt.Fprint(w, `{"message":"Modification of contact was successful!"}`)
+		default:
+			t.Errorf("Unexpected request method: %v", r.Method)
+		}
+	})
+	return &reads
+}
+
+func TestContactService_AddSMSTarget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	handleTargetsContact(t, &put, nil)
+
+	err := client.Contacts.AddSMSTarget(12941, SMSNotification{CountryCode: "+44", Number: "020 7946 0000", Provider: "nexmo", Severity: "high"})
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", put["name"])
+	assert.Equal(t, true, put["paused"])
+	assert.Equal(t, map[string]interface{}{
+		"email": []interface{}{
+			map[string]interface{}{"severity": "HIGH", "address": "johndoe@teamrocket.com"},
+		},
+		"sms": []interface{}{
+			map[string]interface{}{"severity": "HIGH", "country_code": "1", "number": "5555555555", "provider": "nexmo"},
+			map[string]interface{}{"severity": "LOW", "country_code": "46", "number": "701234567", "provider": "nexmo"},
+			map[string]interface{}{"severity": "HIGH", "country_code": "44", "number": "02079460000", "provider": "nexmo"},
+		},
+	}, put["notification_targets"])
+}
+
+func TestContactService_AddSMSTarget_Exists(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	handleTargetsContact(t, &put, nil)
+
+	err := client.Contacts.AddSMSTarget(12941, SMSNotification{CountryCode: "+1", Number: "555-555-5555", Severity: "HIGH"})
+	assert.True(t, errors.Is(err, ErrTargetExists), "got %v", err)
+	assert.Nil(t, put, "Contact should not be updated")
+}
+
+func TestContactService_AddSMSTarget_Invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	err := client.Contacts.AddSMSTarget(12941, SMSNotification{CountryCode: "1", Number: "555-CALL-NOW"})
+	assert.IsType(t, TargetErrors{}, err)
+}
+
+func TestContactService_RemoveSMSTarget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	handleTargetsContact(t, &put, nil)
+
+	err := client.Contacts.RemoveSMSTarget(12941, SMSNotification{CountryCode: "1", Number: "(555) 555-5555"})
+	assert.NoError(t, err)
+	targets := put["notification_targets"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"severity": "LOW", "country_code": "46", "number": "701234567", "provider": "nexmo"},
+	}, targets["sms"])
+	assert.Len(t, targets["email"], 1)
+
+	put = nil
+	err = client.Contacts.RemoveSMSTarget(12941, SMSNotification{CountryCode: "1", Number: "5550000000"})
+	assert.True(t, errors.Is(err, ErrTargetNotFound), "got %v", err)
+	assert.Nil(t, put)
+}
+
+func TestContactService_ReplaceSMSTarget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	handleTargetsContact(t, &put, nil)
+
+	err := client.Contacts.ReplaceSMSTarget(12941,
+		SMSNotification{CountryCode: "46", Number: "+46 70 123 45 67"},
+		SMSNotification{CountryCode: "46", Number: "709876543", Provider: "nexmo", Severity: "HIGH"})
+	assert.NoError(t, err)
+	targets := put["notification_targets"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"severity": "HIGH", "country_code": "1", "number": "5555555555", "provider": "nexmo"},
+		map[string]interface{}{"severity": "HIGH", "country_code": "46", "number": "709876543", "provider": "nexmo"},
+	}, targets["sms"])
+
+	put = nil
+	err = client.Contacts.ReplaceSMSTarget(12941,
+		SMSNotification{CountryCode: "46", Number: "701234567"},
+		SMSNotification{CountryCode: "1", Number: "5555555555", Severity: "LOW"})
+	assert.True(t, errors.Is(err, ErrTargetExists), "got %v", err)
+	assert.Nil(t, put)
+}
+
+func TestContactService_EmailTargets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	handleTargetsContact(t, &put, nil)
+
+	err := client.Contacts.AddEmailTarget(12941, EmailNotification{Address: "oncall@teamrocket.com", Severity: "LOW"})
+	assert.NoError(t, err)
+	targets := put["notification_targets"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"severity": "HIGH", "address": "johndoe@teamrocket.com"},
+		map[string]interface{}{"severity": "LOW", "address": "oncall@teamrocket.com"},
+	}, targets["email"])
+	assert.Len(t, targets["sms"], 2)
+
+	put = nil
+	err = client.Contacts.AddEmailTarget(12941, EmailNotification{Address: "JohnDoe@TeamRocket.com", Severity: "LOW"})
+	assert.True(t, errors.Is(err, ErrTargetExists), "got %v", err)
+
+	err = client.Contacts.ReplaceEmailTarget(12941,
+		EmailNotification{Address: "johndoe@teamrocket.com"},
+		EmailNotification{Address: "john@teamrocket.com", Severity: "HIGH"})
+	assert.NoError(t, err)
+	targets = put["notification_targets"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"severity": "HIGH", "address": "john@teamrocket.com"},
+	}, targets["email"])
+
+	put = nil
+	err = client.Contacts.RemoveEmailTarget(12941, EmailNotification{Address: "johndoe@teamrocket.com"})
+	assert.NoError(t, err)
+	targets = put["notification_targets"].(map[string]interface{})
+	assert.NotContains(t, targets, "email")
+
+	err = client.Contacts.RemoveEmailTarget(12941, EmailNotification{Address: "nobody@teamrocket.com"})
+	assert.True(t, errors.Is(err, ErrTargetNotFound), "got %v", err)
+}
+
+func TestContactService_ModifyTargets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	reads := handleTargetsContact(t, &put, nil)
+
+	err := client.Contacts.AddEmailTarget(12941, EmailNotification{Address: "oncall@teamrocket.com", Severity: "LOW"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *reads, "The contact is read again before being updated")
+	assert.Equal(t, "John Doe", put["name"])
+}
+
+func TestContactService_ModifyTargets_Conflict(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put map[string]interface{}
+	handleTargetsContact(t, &put, func() string {
+		return `{"contact": {"id": 12941, "name": "John Doe", "paused": true, "notification_targets": {}}}`
+	})
+
+	err := client.Contacts.AddEmailTarget(12941, EmailNotification{Address: "oncall@teamrocket.com", Severity: "LOW"})
+	assert.True(t, errors.Is(err, ErrContactModified), "got %v", err)
+	assert.EqualError(t, err, "contact was modified concurrently: contact 12941")
+	assert.Nil(t, put, "Contact should not be updated")
+}
@@ -57,20 +57,22 @@ type Contact struct {
 	Type                string              `json:"type"`
 }
 
-// ValidContact determines whether a Contact contains valid fields.
+// ValidContact determines whether a Contact contains valid fields.  Invalid
+// notification targets are reported together as TargetErrors.
 func (c *Contact) ValidContact() error {
 	if c.Name == "" {
 		return fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string")
 	}
 
-	return nil
+	return c.NotificationTargets.Valid()
 }
 
-// RenderForJSONAPI returns the JSON formatted version of this object that may be submitted to Pingdom
+// RenderForJSONAPI returns the JSON formatted version of this object that may be submitted to Pingdom.
+// The notification targets are normalized first.
 func (c *Contact) RenderForJSONAPI() string {
 	u := map[string]interface{}{
 		"name":                 c.Name,
-		"notification_targets": c.NotificationTargets,
+		"notification_targets": c.NotificationTargets.Normalized(),
 		"paused":               c.Paused,
 	}
 	jsonBody, _ := json.Marshal(u)
@@ -0,0 +1,224 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// SMS providers supported by the API.
+const (
+	SMSProviderNexmo    = "nexmo"
+	SMSProviderBulkSMS  = "bulksms"
+	SMSProviderEsendex  = "esendex"
+	SMSProviderCellsynt = "cellsynt"
+)
+
+// SMSProviders is the list of SMS providers supported by the API.
+var SMSProviders = []string{SMSProviderNexmo, SMSProviderBulkSMS, SMSProviderEsendex, SMSProviderCellsynt}
+
+// Severities of alerts a notification target receives.
+const (
+	SeverityHigh = "HIGH"
+	SeverityLow  = "LOW"
+)
+
+// maxPhoneDigits is the maximum number of digits of an international phone
+// number including the country code (ITU-T E.164).
+const maxPhoneDigits = 15
+
+// TargetError describes an invalid field of a notification target.
+type TargetError struct {
+	// Field is the path of the invalid field, e.g. "SMS[0].Number".
+	Field   string
+	Value   string
+	Message string
+}
+
+func (e *TargetError) Error() string {
+	return fmt.Sprintf("Invalid value %q for `%s`.  %s", e.Value, e.Field, e.Message)
+}
+
+// TargetErrors is returned when notification targets are invalid.  It lists
+// every invalid field rather than only the first.
+type TargetErrors []*TargetError
+
+func (e TargetErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// NormalizePhoneNumber returns a country code and number in the form
+// expected by the API: digits only, without a leading "+" on the country
+// code or separators such as spaces, dashes and parentheses in the number.
+// A number given in international format with the same country code, e.g.
+// "+46 70 123 45 67" for country code "46", is converted to the national
+// part.
+func NormalizePhoneNumber(countryCode, number string) (string, string) {
+	countryCode = strings.TrimPrefix(strings.TrimSpace(countryCode), "+")
+
+	number = strings.TrimSpace(number)
+	international := strings.HasPrefix(number, "+")
+	number = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '/', '+':
+			return -1
+		}
+		return r
+	}, number)
+	if international && countryCode != "" {
+		number = strings.TrimPrefix(number, countryCode)
+	}
+	return countryCode, number
+}
+
+// Normalized returns the notification with its phone number normalized with
+// NormalizePhoneNumber, the provider in lower case and the severity in upper
+// case.
+func (n SMSNotification) Normalized() SMSNotification {
+	n.CountryCode, n.Number = NormalizePhoneNumber(n.CountryCode, n.Number)
+	n.Provider = strings.ToLower(strings.TrimSpace(n.Provider))
+	n.Severity = normalizeSeverity(n.Severity)
+	return n
+}
+
+// Normalized returns the notification with surrounding space removed from
+// the address and the severity in upper case.
+func (n EmailNotification) Normalized() EmailNotification {
+	n.Address = strings.TrimSpace(n.Address)
+	n.Severity = normalizeSeverity(n.Severity)
+	return n
+}
+
+// Normalized returns the notification with the severity in upper case.
+func (n APNSNotification) Normalized() APNSNotification {
+	n.Severity = normalizeSeverity(n.Severity)
+	return n
+}
+
+// Normalized returns the notification with the severity in upper case.
+func (n AGCMNotification) Normalized() AGCMNotification {
+	n.Severity = normalizeSeverity(n.Severity)
+	return n
+}
+
+// Normalized returns a copy of the targets with every target normalized.
+func (t NotificationTargets) Normalized() NotificationTargets {
+	n := NotificationTargets{}
+	if t.SMS != nil {
+		n.SMS = make([]SMSNotification, len(t.SMS))
+		for i, sms := range t.SMS {
+			n.SMS[i] = sms.Normalized()
+		}
+	}
+	if t.Email != nil {
+		n.Email = make([]EmailNotification, len(t.Email))
+		for i, email := range t.Email {
+			n.Email[i] = email.Normalized()
+		}
+	}
+	if t.APNS != nil {
+		n.APNS = make([]APNSNotification, len(t.APNS))
+		for i, apns := range t.APNS {
+			n.APNS[i] = apns.Normalized()
+		}
+	}
+	if t.AGCM != nil {
+		n.AGCM = make([]AGCMNotification, len(t.AGCM))
+		for i, agcm := range t.AGCM {
+			n.AGCM[i] = agcm.Normalized()
+		}
+	}
+	return n
+}
+
+// Valid determines whether every target, once normalized, can be used to
+// send alerts.  Every target must have a severity, as the API rejects targets
+// without one.  The returned error is a TargetErrors listing every invalid
+// field.
+func (t NotificationTargets) Valid() error {
+	var errs TargetErrors
+	add := func(field, value, format string, args ...interface{}) {
+		errs = append(errs, &TargetError{Field: field, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+	severity := func(field, value string) {
+		if value != SeverityHigh && value != SeverityLow {
+			add(field, value, "Must be %s or %s", SeverityHigh, SeverityLow)
+		}
+	}
+
+	for i, sms := range t.SMS {
+		field := fmt.Sprintf("SMS[%d]", i)
+		n := sms.Normalized()
+		switch {
+		case !isDigits(n.CountryCode) || len(n.CountryCode) > 3:
+			add(field+".CountryCode", sms.CountryCode, "Must contain 1 to 3 digits")
+		case !isDigits(n.Number) || len(n.Number) < 4:
+			add(field+".Number", sms.Number, "Must contain a phone number of at least 4 digits")
+		case len(n.CountryCode)+len(n.Number) > maxPhoneDigits:
+			add(field+".Number", sms.Number, "Must not exceed %d digits including the country code", maxPhoneDigits)
+		}
+		if n.Provider != "" && !contains(SMSProviders, n.Provider) {
+			add(field+".Provider", sms.Provider, "Must be one of %v", SMSProviders)
+		}
+		severity(field+".Severity", n.Severity)
+	}
+
+	for i, email := range t.Email {
+		field := fmt.Sprintf("Email[%d]", i)
+		n := email.Normalized()
+		if addr, err := mail.ParseAddress(n.Address); err != nil || addr.Name != "" || addr.Address != n.Address {
+			add(field+".Address", email.Address, "Must contain a plain email address")
+		}
+		severity(field+".Severity", n.Severity)
+	}
+
+	for i, apns := range t.APNS {
+		field := fmt.Sprintf("APNS[%d]", i)
+		if apns.Device == "" {
+			add(field+".Device", apns.Device, "Must contain non-empty string")
+		}
+		severity(field+".Severity", apns.Normalized().Severity)
+	}
+
+	for i, agcm := range t.AGCM {
+		field := fmt.Sprintf("AGCM[%d]", i)
+		if agcm.AGCMID == "" {
+			add(field+".AGCMID", agcm.AGCMID, "Must contain non-empty string")
+		}
+		severity(field+".Severity", agcm.Normalized().Severity)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func normalizeSeverity(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
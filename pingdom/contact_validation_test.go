@@ -0,0 +1,148 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		countryCode, number string
+		wantCC, wantNumber  string
+	}{
+		{"1", "5555555555", "1", "5555555555"},
+		{"+1", "(555) 555-5555", "1", "5555555555"},
+		{" 46 ", "070.123 45 67", "46", "0701234567"},
+		{"46", "+46 70 123 45 67", "46", "701234567"},
+		{"00", "12345", "00", "12345"},
+		{"", "+1 555", "", "1555"},
+	}
+	for _, tt := range tests {
+		cc, number := NormalizePhoneNumber(tt.countryCode, tt.number)
+		assert.Equal(t, tt.wantCC, cc, "country code of %q %q", tt.countryCode, tt.number)
+		assert.Equal(t, tt.wantNumber, number, "number of %q %q", tt.countryCode, tt.number)
+	}
+}
+
+func TestNotificationTargetsNormalized(t *testing.T) {
+	targets := NotificationTargets{
+		SMS:   []SMSNotification{{CountryCode: "+1", Number: "555-555-5555", Provider: " Nexmo", Severity: "high"}},
+		Email: []EmailNotification{{Address: " jane@example.com ", Severity: "low"}},
+		APNS:  []APNSNotification{{Device: "device", Severity: "High"}},
+	}
+
+	got := targets.Normalized()
+
+	assert.Equal(t, NotificationTargets{
+		SMS:   []SMSNotification{{CountryCode: "1", Number: "5555555555", Provider: "nexmo", Severity: "HIGH"}},
+		Email: []EmailNotification{{Address: "jane@example.com", Severity: "LOW"}},
+		APNS:  []APNSNotification{{Device: "device", Severity: "HIGH"}},
+	}, got)
+	assert.Nil(t, got.AGCM)
+	assert.Equal(t, "+1", targets.SMS[0].CountryCode, "Normalized should not modify the receiver")
+}
+
+func TestNotificationTargetsValid(t *testing.T) {
+	valid := NotificationTargets{
+		SMS: []SMSNotification{
+			{CountryCode: "00", Number: "111111111", Provider: "nexmo", Severity: "HIGH"},
+			{CountryCode: "+44", Number: "020 7946 0000", Provider: "BulkSMS", Severity: "high"},
+		},
+		Email: []EmailNotification{{Address: "jane@example.com", Severity: "low"}},
+		APNS:  []APNSNotification{{Device: "device", Severity: "LOW"}},
+		AGCM:  []AGCMNotification{{AGCMID: "agcm", Severity: " High "}},
+	}
+	assert.NoError(t, valid.Valid())
+	assert.NoError(t, NotificationTargets{}.Valid())
+}
+
+func TestNotificationTargetsValid_Invalid(t *testing.T) {
+	targets := NotificationTargets{
+		SMS: []SMSNotification{
+			{CountryCode: "1", Number: "5555555555", Provider: "verizon", Severity: "HIGH"},
+			{CountryCode: "abc", Number: "5555555555", Severity: "HIGH"},
+			{CountryCode: "1", Number: "555-CALL-NOW", Severity: "HIGH"},
+			{CountryCode: "1", Number: "123456789012345", Severity: "HIGH"},
+			{CountryCode: "1", Number: "5555555555", Severity: "MEDIUM"},
+		},
+		Email: []EmailNotification{
+			{Address: "not an email", Severity: "LOW"},
+			{Address: "Jane <jane@example.com>", Severity: "LOW"},
+			{Address: "jane@example.com", Severity: "urgent"},
+			{Address: "jane@example.com", Severity: " "},
+		},
+		APNS: []APNSNotification{{Severity: "HIGH"}},
+		AGCM: []AGCMNotification{{AGCMID: "agcm", Severity: "x"}},
+	}
+
+	err := targets.Valid()
+
+	errs, ok := err.(TargetErrors)
+	if !assert.True(t, ok, "Valid should return TargetErrors, got %T", err) {
+		return
+	}
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	assert.Equal(t, []string{
+		"SMS[0].Provider",
+		"SMS[1].CountryCode",
+		"SMS[2].Number",
+		"SMS[3].Number",
+		"SMS[4].Severity",
+		"Email[0].Address",
+		"Email[1].Address",
+		"Email[2].Severity",
+		"Email[3].Severity",
+		"APNS[0].Device",
+		"AGCM[0].Severity",
+	}, fields)
+	assert.Equal(t, "555-CALL-NOW", errs[2].Value)
+	assert.Contains(t, err.Error(), "Invalid value \"verizon\" for `SMS[0].Provider`.  Must be one of [nexmo bulksms esendex cellsynt]")
+}
+
+func TestNotificationTargetsValid_NoSeverity(t *testing.T) {
+	targets := NotificationTargets{
+		SMS:   []SMSNotification{{CountryCode: "1", Number: "5555555555", Provider: "nexmo"}},
+		Email: []EmailNotification{{Address: "jane@example.com"}},
+		APNS:  []APNSNotification{{Device: "device"}},
+		AGCM:  []AGCMNotification{{AGCMID: "agcm"}},
+	}
+
+	err := targets.Valid()
+
+	assert.EqualError(t, err, "Invalid value \"\" for `SMS[0].Severity`.  Must be HIGH or LOW; "+
+		"Invalid value \"\" for `Email[0].Severity`.  Must be HIGH or LOW; "+
+		"Invalid value \"\" for `APNS[0].Severity`.  Must be HIGH or LOW; "+
+		"Invalid value \"\" for `AGCM[0].Severity`.  Must be HIGH or LOW")
+}
+
+func TestContact_ValidContact_Targets(t *testing.T) {
+	contact := Contact{
+		Name: "Jane",
+		NotificationTargets: NotificationTargets{
+			Email: []EmailNotification{{Address: "jane"}},
+		},
+	}
+
+	err := contact.ValidContact()
+
+	assert.IsType(t, TargetErrors{}, err)
+}
+
+func TestContact_RenderForJSONAPI_Normalized(t *testing.T) {
+	contact := Contact{
+		Name: "Jane",
+		NotificationTargets: NotificationTargets{
+			SMS: []SMSNotification{{CountryCode: "+1", Number: "555 555 5555", Severity: "high"}},
+		},
+	}
+
+	assert.JSONEq(t, `{
+		"name": "Jane",
+		"paused": false,
+		"notification_targets": {"sms": [{"country_code": "1", "number": "5555555555", "provider": "", "severity": "HIGH"}]}
+	}`, contact.RenderForJSONAPI())
+}
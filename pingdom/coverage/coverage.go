@@ -0,0 +1,278 @@
+/*
+Package coverage finds checks whose alerts reach nobody.
+
+A check alerts the contacts in its UserIds and the members of its teams.
+Alerts are lost when these are missing, all paused, or only have notification
+targets for the other severity.  Analyze joins checks, contacts and teams and
+reports such gaps:
+
+	report, err := coverage.Analyze(coverage.Config{
+		Checks:   client.Checks,
+		Contacts: client.Contacts,
+		Teams:    client.Teams,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, gap := range report.Checks {
+		log.Printf("%s: %v", gap.Check.Name, gap.Problems)
+	}
+
+The recipients of a check are only returned by CheckService.Read, so
+Analyze reads every listed check.
+*/
+package coverage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Problem is a reason why alerts of a check may reach nobody.
+type Problem string
+
+const (
+	// NoRecipients is reported for checks without contacts, either
+	// directly or through a team.
+	NoRecipients Problem = "no_recipients"
+
+	// AllRecipientsPaused is reported when every recipient of a check is
+	// paused.
+	AllRecipientsPaused Problem = "all_recipients_paused"
+
+	// NoTargetForSeverity is reported when no active recipient of a check
+	// has a notification target for the severity level of the check.
+	NoTargetForSeverity Problem = "no_target_for_severity"
+
+	// UnknownRecipients is reported when a check refers to contacts or
+	// teams that do not exist.
+	UnknownRecipients Problem = "unknown_recipients"
+)
+
+// Config configures Analyze.  The services are usually those of a
+// pingdom.Client.
+type Config struct {
+	Checks   pingdom.CheckAPI
+	Contacts pingdom.ContactServiceAPI
+	Teams    pingdom.TeamServiceAPI
+
+	// Params are passed to Checks.List, e.g. to only analyze checks with
+	// certain tags.  Contacts only referenced by other checks are then
+	// reported as orphaned.
+	Params map[string]string
+}
+
+// CheckGap describes a check whose alerts may reach nobody.
+type CheckGap struct {
+	Check    pingdom.CheckResponse
+	Problems []Problem
+
+	// Recipients are the contacts alerted by the check, directly or
+	// through a team, ordered by ID.
+	Recipients []pingdom.Contact
+
+	// UnknownContactIDs and UnknownTeamIDs are the IDs the check refers to
+	// that do not exist.
+	UnknownContactIDs []int
+	UnknownTeamIDs    []int
+}
+
+// Report is the result of Analyze.  Every list is ordered by ID.
+type Report struct {
+	// Checks are the unpaused checks with at least one problem.  Paused
+	// checks do not alert and are not reported.
+	Checks []CheckGap
+
+	// ContactsWithoutHighTarget are the contacts without any notification
+	// target of severity HIGH.
+	ContactsWithoutHighTarget []pingdom.Contact
+
+	// EmptyTeams are the teams without members.
+	EmptyTeams []pingdom.TeamResponse
+
+	// OrphanedContacts are the contacts that are not alerted by any of the
+	// analyzed checks, directly or through a team.
+	OrphanedContacts []pingdom.Contact
+}
+
+// OK reports whether no gaps were found.
+func (r *Report) OK() bool {
+	return len(r.Checks) == 0 && len(r.ContactsWithoutHighTarget) == 0 &&
+		len(r.EmptyTeams) == 0 && len(r.OrphanedContacts) == 0
+}
+
+// Analyze lists and reads the checks, contacts and teams and reports the
+// gaps in alert coverage.
+func Analyze(config Config) (*Report, error) {
+	contacts, err := config.Contacts.List()
+	if err != nil {
+		return nil, err
+	}
+	teams, err := config.Teams.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var params []map[string]string
+	if config.Params != nil {
+		params = append(params, config.Params)
+	}
+	list, err := config.Checks.List(params...)
+	if err != nil {
+		return nil, err
+	}
+	checks := make([]pingdom.CheckResponse, 0, len(list))
+	for _, c := range list {
+		check, err := config.Checks.Read(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, *check)
+	}
+
+	return analyze(checks, contacts, teams), nil
+}
+
+// analyze builds the report from the checks, as returned by
+// CheckService.Read, the contacts and the teams.
+func analyze(checks []pingdom.CheckResponse, contacts []pingdom.Contact, teams []pingdom.TeamResponse) *Report {
+	sort.Slice(checks, func(i, j int) bool { return checks[i].ID < checks[j].ID })
+	sort.Slice(contacts, func(i, j int) bool { return contacts[i].ID < contacts[j].ID })
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+
+	contactsByID := make(map[int]pingdom.Contact, len(contacts))
+	for _, c := range contacts {
+		contactsByID[c.ID] = c
+	}
+	teamsByID := make(map[int]pingdom.TeamResponse, len(teams))
+	for _, t := range teams {
+		teamsByID[t.ID] = t
+	}
+
+	report := &Report{}
+	alerted := map[int]bool{}
+	for _, check := range checks {
+		gap := CheckGap{Check: check}
+		recipients := map[int]bool{}
+		for _, id := range check.UserIds {
+			if _, ok := contactsByID[id]; ok {
+				recipients[id] = true
+			} else {
+				gap.UnknownContactIDs = append(gap.UnknownContactIDs, id)
+			}
+		}
+		for _, id := range teamIDs(check) {
+			team, ok := teamsByID[id]
+			if !ok {
+				gap.UnknownTeamIDs = append(gap.UnknownTeamIDs, id)
+				continue
+			}
+			for _, m := range team.Members {
+				if _, ok := contactsByID[m.ID]; ok {
+					recipients[m.ID] = true
+				}
+			}
+		}
+		for id := range recipients {
+			alerted[id] = true
+			gap.Recipients = append(gap.Recipients, contactsByID[id])
+		}
+		sort.Slice(gap.Recipients, func(i, j int) bool { return gap.Recipients[i].ID < gap.Recipients[j].ID })
+
+		if check.Paused {
+			continue
+		}
+		gap.Problems = problems(check, gap)
+		if len(gap.Problems) > 0 {
+			report.Checks = append(report.Checks, gap)
+		}
+	}
+
+	for _, c := range contacts {
+		if !hasTarget(c, pingdom.SeverityHigh) {
+			report.ContactsWithoutHighTarget = append(report.ContactsWithoutHighTarget, c)
+		}
+		if !alerted[c.ID] {
+			report.OrphanedContacts = append(report.OrphanedContacts, c)
+		}
+	}
+	for _, t := range teams {
+		if len(t.Members) == 0 {
+			report.EmptyTeams = append(report.EmptyTeams, t)
+		}
+	}
+	return report
+}
+
+func problems(check pingdom.CheckResponse, gap CheckGap) []Problem {
+	var problems []Problem
+	if len(gap.UnknownContactIDs) > 0 || len(gap.UnknownTeamIDs) > 0 {
+		problems = append(problems, UnknownRecipients)
+	}
+	if len(gap.Recipients) == 0 {
+		return append(problems, NoRecipients)
+	}
+
+	severity := strings.ToUpper(check.SeverityLevel)
+	if severity == "" {
+		severity = pingdom.SeverityHigh
+	}
+	paused, targeted := true, false
+	for _, c := range gap.Recipients {
+		if c.Paused {
+			continue
+		}
+		paused = false
+		if hasTarget(c, severity) {
+			targeted = true
+		}
+	}
+	switch {
+	case paused:
+		problems = append(problems, AllRecipientsPaused)
+	case !targeted:
+		problems = append(problems, NoTargetForSeverity)
+	}
+	return problems
+}
+
+// teamIDs returns the IDs of the teams of a check.
+func teamIDs(check pingdom.CheckResponse) []int {
+	if len(check.TeamIds) > 0 {
+		return check.TeamIds
+	}
+	ids := make([]int, len(check.Teams))
+	for i, t := range check.Teams {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// hasTarget reports whether the contact has a notification target of the
+// given severity.
+func hasTarget(c pingdom.Contact, severity string) bool {
+	targets := c.NotificationTargets.Normalized()
+	for _, t := range targets.SMS {
+		if t.Severity == severity {
+			return true
+		}
+	}
+	for _, t := range targets.Email {
+		if t.Severity == severity {
+			return true
+		}
+	}
+	for _, t := range targets.APNS {
+		if t.Severity == severity {
+			return true
+		}
+	}
+	for _, t := range targets.AGCM {
+		if t.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
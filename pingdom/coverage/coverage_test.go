@@ -0,0 +1,143 @@
+package coverage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/stretchr/testify/assert"
+)
+
+func contact(id int, paused bool, severities ...string) pingdom.Contact {
+	c := pingdom.Contact{ID: id, Name: "contact", Paused: paused}
+	for _, s := range severities {
+		c.NotificationTargets.Email = append(c.NotificationTargets.Email, pingdom.EmailNotification{Address: "a@example.com", Severity: s})
+	}
+	return c
+}
+
+func team(id int, members ...int) pingdom.TeamResponse {
+	t := pingdom.TeamResponse{ID: id, Name: "team"}
+	for _, m := range members {
+		t.Members = append(t.Members, pingdom.TeamMemberResponse{ID: m, Type: "contact"})
+	}
+	return t
+}
+
+func config(checks []pingdom.CheckResponse, contacts []pingdom.Contact, teams []pingdom.TeamResponse) Config {
+	byID := map[int]pingdom.CheckResponse{}
+	list := make([]pingdom.CheckResponse, len(checks))
+	for i, c := range checks {
+		byID[c.ID] = c
+		list[i] = pingdom.CheckResponse{ID: c.ID, Name: c.Name, Paused: c.Paused}
+	}
+	return Config{
+		Checks: &pingdommock.CheckAPI{
+			ListFunc: func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+				return list, nil
+			},
+			ReadFunc: func(id int) (*pingdom.CheckResponse, error) {
+				c := byID[id]
+				return &c, nil
+			},
+		},
+		Contacts: &pingdommock.ContactServiceAPI{
+			ListFunc: func() ([]pingdom.Contact, error) { return contacts, nil },
+		},
+		Teams: &pingdommock.TeamServiceAPI{
+			ListFunc: func() ([]pingdom.TeamResponse, error) { return teams, nil },
+		},
+	}
+}
+
+func checkProblems(r *Report) map[int][]Problem {
+	problems := map[int][]Problem{}
+	for _, gap := range r.Checks {
+		problems[gap.Check.ID] = gap.Problems
+	}
+	return problems
+}
+
+func TestAnalyze(t *testing.T) {
+	contacts := []pingdom.Contact{
+		contact(1, false, "HIGH"),
+		contact(2, true, "HIGH"),
+		contact(3, false, "low"),
+		contact(4, false, "HIGH", "LOW"),
+		contact(5, false, "HIGH"),
+	}
+	teams := []pingdom.TeamResponse{
+		team(10, 1, 3),
+		team(11),
+		team(12, 2),
+	}
+	checks := []pingdom.CheckResponse{
+		{ID: 100, Name: "covered", UserIds: []int{1}},
+		{ID: 101, Name: "nobody"},
+		{ID: 102, Name: "empty team", TeamIds: []int{11}},
+		{ID: 103, Name: "paused", UserIds: []int{2}, TeamIds: []int{12}},
+		{ID: 104, Name: "low only", UserIds: []int{3}},
+		{ID: 105, Name: "low check", UserIds: []int{3}, SeverityLevel: "LOW"},
+		{ID: 106, Name: "unknown", UserIds: []int{4, 99}, Teams: []pingdom.CheckTeamResponse{{ID: 98}}},
+		{ID: 107, Name: "paused check", Paused: true},
+		{ID: 108, Name: "via team", TeamIds: []int{10}},
+	}
+
+	report, err := Analyze(config(checks, contacts, teams))
+	assert.NoError(t, err)
+	assert.False(t, report.OK())
+
+	assert.Equal(t, map[int][]Problem{
+		101: {NoRecipients},
+		102: {NoRecipients},
+		103: {AllRecipientsPaused},
+		104: {NoTargetForSeverity},
+		106: {UnknownRecipients},
+	}, checkProblems(report))
+
+	gap := report.Checks[4]
+	assert.Equal(t, []int{99}, gap.UnknownContactIDs)
+	assert.Equal(t, []int{98}, gap.UnknownTeamIDs)
+	assert.Len(t, gap.Recipients, 1)
+
+	assert.Equal(t, []pingdom.Contact{contacts[2]}, report.ContactsWithoutHighTarget)
+	assert.Equal(t, []pingdom.TeamResponse{teams[1]}, report.EmptyTeams)
+	assert.Equal(t, []pingdom.Contact{contacts[4]}, report.OrphanedContacts)
+}
+
+func TestAnalyze_OK(t *testing.T) {
+	report, err := Analyze(config(
+		[]pingdom.CheckResponse{{ID: 1, UserIds: []int{1}}},
+		[]pingdom.Contact{contact(1, false, "HIGH")},
+		nil,
+	))
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+}
+
+func TestAnalyze_Params(t *testing.T) {
+	cfg := config(nil, nil, nil)
+	cfg.Params = map[string]string{"tags": "prod"}
+	var got []map[string]string
+	cfg.Checks.(*pingdommock.CheckAPI).ListFunc = func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+		got = params
+		return nil, nil
+	}
+
+	_, err := Analyze(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"tags": "prod"}}, got)
+}
+
+func TestAnalyze_Error(t *testing.T) {
+	cfg := config([]pingdom.CheckResponse{{ID: 1}}, nil, nil)
+	failure := errors.New("failure")
+	cfg.Checks.(*pingdommock.CheckAPI).ReadFunc = func(id int) (*pingdom.CheckResponse, error) {
+		return nil, failure
+	}
+
+	report, err := Analyze(cfg)
+	assert.Equal(t, failure, err)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,443 @@
+/*
+Package exporter exports the status and performance of Pingdom checks as
+Prometheus metrics.
+
+The exporter pulls data from the Pingdom API in the background and serves
+the cached data when it is scraped, so that scrapes are fast and frequent
+scrapes, or several Prometheus servers scraping the same exporter, do not
+use up the API quota:
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "pingdom_api_token"})
+	e := exporter.New(exporter.Config{Checks: client.Checks})
+	go e.Run(ctx)
+	http.Handle("/metrics", e.Handler())
+
+Every check metric is labelled with the ID, name, type, hostname and tags of
+the check.  The check list is refreshed every CheckInterval.  Performance
+summaries and, if enabled, the latest results per probe require a request
+per check and are refreshed every PerformanceInterval.  A check whose
+performance cannot be fetched keeps its previous data and is retried on the
+next refresh, without affecting the other checks.
+*/
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const (
+	defaultNamespace           = "pingdom"
+	defaultCheckInterval       = time.Minute
+	defaultPerformanceInterval = time.Hour
+	defaultConcurrency         = 4
+)
+
+// checkLabels are the labels of every check metric.
+var checkLabels = []string{"id", "name", "type", "hostname", "tags"}
+
+// Config configures an Exporter.
+type Config struct {
+	// Checks is used to fetch checks, performance summaries and results.
+	// It is usually the Checks service of a pingdom.Client.
+	Checks pingdom.CheckAPI
+
+	// Namespace is the namespace of the metric names, "pingdom" by default.
+	Namespace string
+
+	// CheckInterval is how long the check list is cached.  It defaults to
+	// one minute.
+	CheckInterval time.Duration
+
+	// PerformanceInterval is how long performance summaries and results are
+	// cached.  It defaults to one hour.  A negative interval disables them.
+	PerformanceInterval time.Duration
+
+	// Results enables the response time of the latest result of each probe.
+	Results bool
+
+	// Concurrency is the number of checks whose performance is fetched at
+	// the same time.  It defaults to 4.
+	Concurrency int
+
+	// OnError, if set, is called by Run with the error of each refresh
+	// that failed.
+	OnError func(error)
+}
+
+// Exporter is a prometheus.Collector exporting Pingdom checks.
+type Exporter struct {
+	config Config
+	now    func() time.Time
+
+	// refreshMu serialises refreshes and mu guards the cached data, so
+	// that scrapes are not blocked by a refresh in progress.
+	refreshMu    sync.Mutex
+	mu           sync.Mutex
+	checks       []pingdom.CheckResponse
+	checksAt     time.Time
+	performance  map[int]performance
+	failedChecks int
+	lastErr      error
+	refreshedAt  time.Time
+
+	status              *prometheus.Desc
+	up                  *prometheus.Desc
+	paused              *prometheus.Desc
+	lastResponseTime    *prometheus.Desc
+	lastTest            *prometheus.Desc
+	lastError           *prometheus.Desc
+	avgResponseTime     *prometheus.Desc
+	uptimeRatio         *prometheus.Desc
+	probeResponseTime   *prometheus.Desc
+	exporterUp          *prometheus.Desc
+	exporterRefreshedAt *prometheus.Desc
+	exporterCheckErrors *prometheus.Desc
+}
+
+// performance is the cached performance of a single check.
+type performance struct {
+	summary   *pingdom.SummaryPerformanceSummary
+	probes    map[int]pingdom.Result
+	fetchedAt time.Time
+}
+
+// New returns an Exporter with the given configuration.
+func New(config Config) *Exporter {
+	if config.Namespace == "" {
+		config.Namespace = defaultNamespace
+	}
+	if config.CheckInterval == 0 {
+		config.CheckInterval = defaultCheckInterval
+	}
+	if config.PerformanceInterval == 0 {
+		config.PerformanceInterval = defaultPerformanceInterval
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
+
+	ns := config.Namespace
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(ns, "", name), help, labels, nil)
+	}
+	checkDesc := func(name, help string, labels ...string) *prometheus.Desc {
+		return desc(name, help, append(append([]string{}, checkLabels...), labels...)...)
+	}
+
+	return &Exporter{
+		config:              config,
+		now:                 time.Now,
+		status:              checkDesc("check_status", "Current status of the check; the value is always 1.", "status"),
+		up:                  checkDesc("check_up", "Whether the check is up.  Paused and unconfirmed checks are not up."),
+		paused:              checkDesc("check_paused", "Whether the check is paused."),
+		lastResponseTime:    checkDesc("check_last_response_time_seconds", "Response time of the last test of the check."),
+		lastTest:            checkDesc("check_last_test_timestamp_seconds", "Time of the last test of the check."),
+		lastError:           checkDesc("check_last_error_timestamp_seconds", "Time of the last error of the check."),
+		avgResponseTime:     checkDesc("check_average_response_time_seconds", "Average response time of the check over the most recent hour."),
+		uptimeRatio:         checkDesc("check_uptime_ratio", "Ratio of monitored time the check was up over the most recent hour."),
+		probeResponseTime:   checkDesc("check_probe_response_time_seconds", "Response time of the latest result of the check from each probe.", "probe"),
+		exporterUp:          desc("exporter_up", "Whether the last refresh of data from the Pingdom API succeeded."),
+		exporterRefreshedAt: desc("exporter_last_refresh_timestamp_seconds", "Time of the last successful refresh of data from the Pingdom API."),
+		exporterCheckErrors: desc("exporter_check_errors", "Number of checks whose performance could not be fetched in the last refresh."),
+		performance:         map[int]performance{},
+	}
+}
+
+// Handler returns an http.Handler serving the metrics of the exporter.
+func (e *Exporter) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		e.status, e.up, e.paused, e.lastResponseTime, e.lastTest, e.lastError,
+		e.avgResponseTime, e.uptimeRatio, e.probeResponseTime,
+		e.exporterUp, e.exporterRefreshedAt, e.exporterCheckErrors,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.  It exports the data cached by
+// the last refresh and never calls the API.  exporter_up is 0 until the
+// first refresh and whenever the last refresh failed.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	up := 1.0
+	if e.lastErr != nil || e.refreshedAt.IsZero() {
+		up = 0
+	}
+	ch <- prometheus.MustNewConstMetric(e.exporterUp, prometheus.GaugeValue, up)
+	if !e.refreshedAt.IsZero() {
+		ch <- prometheus.MustNewConstMetric(e.exporterRefreshedAt, prometheus.GaugeValue, float64(e.refreshedAt.Unix()))
+	}
+	ch <- prometheus.MustNewConstMetric(e.exporterCheckErrors, prometheus.GaugeValue, float64(e.failedChecks))
+
+	for _, check := range e.checks {
+		e.collectCheck(ch, check)
+	}
+}
+
+// Err returns the error of the last refresh, if any.
+func (e *Exporter) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastErr
+}
+
+func (e *Exporter) collectCheck(ch chan<- prometheus.Metric, check pingdom.CheckResponse) {
+	labels := []string{
+		strconv.Itoa(check.ID),
+		check.Name,
+		check.Type.Name,
+		check.Hostname,
+		tagNames(check.Tags),
+	}
+	gauge := func(desc *prometheus.Desc, value float64, extra ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, append(labels, extra...)...)
+	}
+
+	gauge(e.status, 1, check.Status)
+	gauge(e.up, boolValue(check.Status == "up"))
+	gauge(e.paused, boolValue(check.Paused))
+	if check.LastTestTime != 0 {
+		gauge(e.lastTest, float64(check.LastTestTime))
+		gauge(e.lastResponseTime, float64(check.LastResponseTime)/1000)
+	}
+	if check.LastErrorTime != 0 {
+		gauge(e.lastError, float64(check.LastErrorTime))
+	}
+
+	perf, ok := e.performance[check.ID]
+	if !ok {
+		return
+	}
+	if s := perf.summary; s != nil {
+		gauge(e.avgResponseTime, float64(s.AvgResponse)/1000)
+		if monitored := s.Uptime + s.Downtime; monitored > 0 {
+			gauge(e.uptimeRatio, float64(s.Uptime)/float64(monitored))
+		}
+	}
+	for _, probe := range sortedProbes(perf.probes) {
+		gauge(e.probeResponseTime, float64(perf.probes[probe].ResponseTime)/1000, strconv.Itoa(probe))
+	}
+}
+
+// Run refreshes the data now and then every CheckInterval until ctx is
+// done, and returns the error of the context.  The errors of failed
+// refreshes are passed to Config.OnError and reported by Err.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		if err := e.Refresh(); err != nil && e.config.OnError != nil {
+			e.config.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches the data older than the configured intervals.  It is
+// called by Run, and can be called directly instead of running it.  If the
+// check list cannot be fetched, the previous one is kept.  The errors of
+// checks whose performance cannot be fetched are joined in the returned
+// error, and the performance of the other checks is still updated.
+func (e *Exporter) Refresh() error {
+	e.refreshMu.Lock()
+	defer e.refreshMu.Unlock()
+
+	now := e.now()
+	err := e.refreshChecks(now)
+	if e.config.PerformanceInterval >= 0 {
+		err = errors.Join(err, e.refreshPerformance(now))
+	}
+
+	e.mu.Lock()
+	e.lastErr = err
+	e.mu.Unlock()
+	return err
+}
+
+// refreshChecks fetches the check list if it is older than CheckInterval.
+func (e *Exporter) refreshChecks(now time.Time) error {
+	e.mu.Lock()
+	due := e.checksAt.IsZero() || now.Sub(e.checksAt) >= e.config.CheckInterval
+	e.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	checks, err := e.config.Checks.List(map[string]string{"include_tags": "true"})
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.checks = checks
+	e.checksAt = now
+	e.refreshedAt = now
+	return nil
+}
+
+// refreshPerformance fetches the performance of every active check whose
+// performance is older than PerformanceInterval, Concurrency checks at a
+// time.
+func (e *Exporter) refreshPerformance(now time.Time) error {
+	e.mu.Lock()
+	active := map[int]bool{}
+	var due []int
+	for _, check := range e.checks {
+		if check.Paused {
+			continue
+		}
+		active[check.ID] = true
+		if p, ok := e.performance[check.ID]; !ok || now.Sub(p.fetchedAt) >= e.config.PerformanceInterval {
+			due = append(due, check.ID)
+		}
+	}
+	e.mu.Unlock()
+
+	type result struct {
+		id   int
+		perf performance
+		err  error
+	}
+	ids := make(chan int)
+	results := make(chan result)
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				perf, err := e.fetchPerformance(id)
+				perf.fetchedAt = now
+				results <- result{id: id, perf: perf, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, id := range due {
+			ids <- id
+		}
+		close(ids)
+		wg.Wait()
+		close(results)
+	}()
+
+	fetched := map[int]performance{}
+	failed := map[int]error{}
+	for r := range results {
+		if r.err != nil {
+			failed[r.id] = r.err
+			continue
+		}
+		fetched[r.id] = r.perf
+	}
+	var errs []error
+	for _, id := range due {
+		if err, ok := failed[id]; ok {
+			errs = append(errs, fmt.Errorf("check %d: %w", id, err))
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id := range e.performance {
+		if !active[id] {
+			delete(e.performance, id)
+		}
+	}
+	for id, perf := range fetched {
+		e.performance[id] = perf
+	}
+	if len(fetched) > 0 {
+		e.refreshedAt = now
+	}
+	e.failedChecks = len(errs)
+	return errors.Join(errs...)
+}
+
+// fetchPerformance fetches the performance of a check.
+func (e *Exporter) fetchPerformance(id int) (performance, error) {
+	p := performance{}
+	summary, err := e.config.Checks.SummaryPerformance(pingdom.SummaryPerformanceRequest{
+		Id:            id,
+		Resolution:    "hour",
+		IncludeUptime: true,
+	})
+	if err != nil {
+		return p, err
+	}
+	if hours := summary.Summary.Hours; len(hours) > 0 {
+		p.summary = &hours[len(hours)-1]
+	}
+
+	if e.config.Results {
+		results, err := e.config.Checks.Results(id, map[string]string{"limit": "100"})
+		if err != nil {
+			return p, err
+		}
+		p.probes = latestByProbe(results.Results)
+	}
+	return p, nil
+}
+
+// latestByProbe returns the newest result of each probe.
+func latestByProbe(results []pingdom.Result) map[int]pingdom.Result {
+	latest := map[int]pingdom.Result{}
+	for _, r := range results {
+		if prev, ok := latest[r.ProbeID]; !ok || r.Time > prev.Time {
+			latest[r.ProbeID] = r
+		}
+	}
+	return latest
+}
+
+func sortedProbes(results map[int]pingdom.Result) []int {
+	probes := make([]int, 0, len(results))
+	for probe := range results {
+		probes = append(probes, probe)
+	}
+	sort.Ints(probes)
+	return probes
+}
+
+// tagNames returns the sorted names of tags joined with commas.
+func tagNames(tags []pingdom.CheckResponseTag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
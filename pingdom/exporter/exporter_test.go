@@ -0,0 +1,313 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testChecks = []pingdom.CheckResponse{
+	{
+		ID:               85975,
+		Name:             "My check 1",
+		Hostname:         "example.com",
+		Status:           "up",
+		LastTestTime:     1297446423,
+		LastResponseTime: 355,
+		LastErrorTime:    1297446000,
+		Type:             pingdom.CheckResponseType{Name: "http"},
+		Tags:             []pingdom.CheckResponseTag{{Name: "web"}, {Name: "api"}},
+	},
+	{
+		ID:       161748,
+		Name:     "My check 2",
+		Hostname: "mydomain.com",
+		Status:   "paused",
+		Paused:   true,
+		Type:     pingdom.CheckResponseType{Name: "ping"},
+	},
+}
+
+// gauge returns the value of the metric with the given name and no labels.
+func gauge(t *testing.T, e *Exporter, name string) float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == name && len(f.GetMetric()) == 1 {
+			return f.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Errorf("metric %s not found", name)
+	return 0
+}
+
+func newMock() *pingdommock.CheckAPI {
+	return &pingdommock.CheckAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+			return testChecks, nil
+		},
+		SummaryPerformanceFunc: func(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+			return &pingdom.SummaryPerformanceResponse{Summary: pingdom.SummaryPerformanceMap{
+				Hours: []pingdom.SummaryPerformanceSummary{
+					{AvgResponse: 100, StartTime: 1297440000, Uptime: 3600},
+					{AvgResponse: 250, StartTime: 1297443600, Uptime: 2700, Downtime: 900},
+				},
+			}}, nil
+		},
+		ResultsFunc: func(id int, params ...map[string]string) (*pingdom.ResultsResponse, error) {
+			return &pingdom.ResultsResponse{Results: []pingdom.Result{
+				{ProbeID: 33, Time: 1297446423, ResponseTime: 310},
+				{ProbeID: 34, Time: 1297446400, ResponseTime: 420},
+				{ProbeID: 33, Time: 1297446300, ResponseTime: 900},
+			}}, nil
+		},
+	}
+}
+
+func TestExporter(t *testing.T) {
+	e := New(Config{Checks: newMock(), Results: true})
+	assert.NoError(t, e.Refresh())
+
+	expected := `
+# HELP pingdom_check_up Whether the check is up.  Paused and unconfirmed checks are not up.
+# TYPE pingdom_check_up gauge
+pingdom_check_up{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 1
+pingdom_check_up{hostname="mydomain.com",id="161748",name="My check 2",tags="",type="ping"} 0
+# HELP pingdom_check_status Current status of the check; the value is always 1.
+# TYPE pingdom_check_status gauge
+pingdom_check_status{hostname="example.com",id="85975",name="My check 1",status="up",tags="api,web",type="http"} 1
+pingdom_check_status{hostname="mydomain.com",id="161748",name="My check 2",status="paused",tags="",type="ping"} 1
+# HELP pingdom_check_paused Whether the check is paused.
+# TYPE pingdom_check_paused gauge
+pingdom_check_paused{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 0
+pingdom_check_paused{hostname="mydomain.com",id="161748",name="My check 2",tags="",type="ping"} 1
+# HELP pingdom_check_last_response_time_seconds Response time of the last test of the check.
+# TYPE pingdom_check_last_response_time_seconds gauge
+pingdom_check_last_response_time_seconds{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 0.355
+# HELP pingdom_check_last_error_timestamp_seconds Time of the last error of the check.
+# TYPE pingdom_check_last_error_timestamp_seconds gauge
+pingdom_check_last_error_timestamp_seconds{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 1.297446e+09
+# HELP pingdom_check_average_response_time_seconds Average response time of the check over the most recent hour.
+# TYPE pingdom_check_average_response_time_seconds gauge
+pingdom_check_average_response_time_seconds{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 0.25
+# HELP pingdom_check_uptime_ratio Ratio of monitored time the check was up over the most recent hour.
+# TYPE pingdom_check_uptime_ratio gauge
+pingdom_check_uptime_ratio{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 0.75
+# HELP pingdom_check_probe_response_time_seconds Response time of the latest result of the check from each probe.
+# TYPE pingdom_check_probe_response_time_seconds gauge
+pingdom_check_probe_response_time_seconds{hostname="example.com",id="85975",name="My check 1",probe="33",tags="api,web",type="http"} 0.31
+pingdom_check_probe_response_time_seconds{hostname="example.com",id="85975",name="My check 1",probe="34",tags="api,web",type="http"} 0.42
+# HELP pingdom_exporter_up Whether the last refresh of data from the Pingdom API succeeded.
+# TYPE pingdom_exporter_up gauge
+pingdom_exporter_up 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"pingdom_check_up",
+		"pingdom_check_status",
+		"pingdom_check_paused",
+		"pingdom_check_last_response_time_seconds",
+		"pingdom_check_last_error_timestamp_seconds",
+		"pingdom_check_average_response_time_seconds",
+		"pingdom_check_uptime_ratio",
+		"pingdom_check_probe_response_time_seconds",
+		"pingdom_exporter_up",
+	))
+}
+
+func TestExporterCaching(t *testing.T) {
+	mock := newMock()
+	e := New(Config{Checks: mock, CheckInterval: time.Minute, PerformanceInterval: time.Hour})
+	now := time.Unix(1297446423, 0)
+	e.now = func() time.Time { return now }
+
+	count := func(method string) int {
+		n := 0
+		for _, call := range mock.Calls() {
+			if call.Method == method {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.NoError(t, e.Refresh())
+	assert.NoError(t, e.Refresh())
+	testutil.CollectAndCount(e)
+	assert.Equal(t, 1, count("List"))
+	assert.Equal(t, 1, count("SummaryPerformance"))
+	assert.Equal(t, 0, count("Results"))
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, e.Refresh())
+	assert.Equal(t, 2, count("List"))
+	assert.Equal(t, 1, count("SummaryPerformance"))
+
+	now = now.Add(time.Hour)
+	assert.NoError(t, e.Refresh())
+	assert.Equal(t, 3, count("List"))
+	assert.Equal(t, 2, count("SummaryPerformance"))
+}
+
+func TestExporterCollectDoesNotCallAPI(t *testing.T) {
+	mock := newMock()
+	e := New(Config{Checks: mock})
+
+	expected := `
+# HELP pingdom_exporter_up Whether the last refresh of data from the Pingdom API succeeded.
+# TYPE pingdom_exporter_up gauge
+pingdom_exporter_up 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected), "pingdom_exporter_up"))
+	assert.Equal(t, 0, testutil.CollectAndCount(e, "pingdom_check_up"))
+	assert.Empty(t, mock.Calls())
+}
+
+func TestExporterPerformanceDisabled(t *testing.T) {
+	mock := &pingdommock.CheckAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+			assert.Equal(t, []map[string]string{{"include_tags": "true"}}, params)
+			return testChecks, nil
+		},
+	}
+	e := New(Config{Checks: mock, PerformanceInterval: -1})
+	assert.NoError(t, e.Refresh())
+
+	assert.Equal(t, 0, testutil.CollectAndCount(e, "pingdom_check_average_response_time_seconds"))
+	assert.NoError(t, e.Err())
+}
+
+func TestExporterError(t *testing.T) {
+	mock := newMock()
+	e := New(Config{Checks: mock})
+	now := time.Unix(1297446423, 0)
+	e.now = func() time.Time { return now }
+
+	assert.NoError(t, e.Refresh())
+	assert.Equal(t, 2, testutil.CollectAndCount(e, "pingdom_check_up"))
+
+	mock.ListFunc = func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+		return nil, errors.New("rate limited")
+	}
+	now = now.Add(time.Minute)
+	assert.EqualError(t, e.Refresh(), "rate limited")
+
+	expected := `
+# HELP pingdom_exporter_up Whether the last refresh of data from the Pingdom API succeeded.
+# TYPE pingdom_exporter_up gauge
+pingdom_exporter_up 0
+# HELP pingdom_exporter_last_refresh_timestamp_seconds Time of the last successful refresh of data from the Pingdom API.
+# TYPE pingdom_exporter_last_refresh_timestamp_seconds gauge
+pingdom_exporter_last_refresh_timestamp_seconds 1.297446423e+09
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"pingdom_exporter_up", "pingdom_exporter_last_refresh_timestamp_seconds"))
+	assert.EqualError(t, e.Err(), "rate limited")
+	assert.Equal(t, 2, testutil.CollectAndCount(e, "pingdom_check_up"))
+}
+
+func TestExporterCheckError(t *testing.T) {
+	checks := []pingdom.CheckResponse{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	failing := map[int]bool{2: true}
+	var mu sync.Mutex
+	mock := newMock()
+	mock.ListFunc = func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+		return checks, nil
+	}
+	summary := mock.SummaryPerformanceFunc
+	mock.SummaryPerformanceFunc = func(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failing[request.Id] {
+			return nil, errors.New("timeout")
+		}
+		return summary(request)
+	}
+	e := New(Config{Checks: mock, Concurrency: 2})
+	now := time.Unix(1297446423, 0)
+	e.now = func() time.Time { return now }
+
+	assert.EqualError(t, e.Refresh(), "check 2: timeout")
+	assert.Equal(t, 2, testutil.CollectAndCount(e, "pingdom_check_average_response_time_seconds"))
+	assert.Equal(t, 1.0, gauge(t, e, "pingdom_exporter_check_errors"))
+
+	// The failed check is retried on the next refresh, the others are
+	// cached until PerformanceInterval has passed.
+	mu.Lock()
+	failing = map[int]bool{}
+	mu.Unlock()
+	now = now.Add(time.Minute)
+	assert.NoError(t, e.Refresh())
+	assert.Equal(t, 3, testutil.CollectAndCount(e, "pingdom_check_average_response_time_seconds"))
+	assert.Equal(t, 0.0, gauge(t, e, "pingdom_exporter_check_errors"))
+	n := 0
+	for _, call := range mock.Calls() {
+		if call.Method == "SummaryPerformance" {
+			n++
+		}
+	}
+	assert.Equal(t, 4, n)
+}
+
+func TestExporterRun(t *testing.T) {
+	mock := newMock()
+	var mu sync.Mutex
+	var listErr error
+	mock.ListFunc = func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return testChecks, listErr
+	}
+	errs := make(chan error, 1)
+	e := New(Config{Checks: mock, CheckInterval: time.Millisecond, OnError: func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- e.Run(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCount(e, "pingdom_check_up") == 2
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	listErr = errors.New("rate limited")
+	mu.Unlock()
+	select {
+	case err := <-errs:
+		assert.EqualError(t, err, "rate limited")
+	case <-time.After(time.Second):
+		t.Error("OnError was not called")
+	}
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestExporterHandler(t *testing.T) {
+	e := New(Config{Checks: newMock()})
+	assert.NoError(t, e.Refresh())
+
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, _ := ioutil.ReadAll(rec.Body)
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, string(body), `pingdom_check_up{hostname="example.com",id="85975",name="My check 1",tags="api,web",type="http"} 1`)
+}
@@ -0,0 +1,195 @@
+package pingdom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Logger is the interface used to log requests and responses.  It is
+// satisfied by *slog.Logger.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// Redacted replaces credentials in logged requests and responses and in
+// recorded cassettes.
+const Redacted = "REDACTED"
+
+// sensitiveParams are query parameters that carry credentials, such as the
+// "username:password" of an HTTP check.
+var sensitiveParams = []string{"auth"}
+
+// sensitiveHeaders are request headers that carry credentials.
+var sensitiveHeaders = []string{"Authorization"}
+
+// sensitiveKeys are JSON keys whose values are credentials, such as the
+// password of an HTTP check returned by CheckService.Read.
+var sensitiveKeys = []string{"password"}
+
+// logRequests returns middleware that logs requests and responses to logger.
+// It is the innermost middleware so that every attempt is logged as sent.
+func logRequests(logger Logger) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			logger.DebugContext(ctx, "pingdom request",
+				"method", req.Method,
+				"url", RedactURL(req.URL),
+				"headers", RedactHeaders(req.Header),
+				"body", requestBody(req),
+			)
+
+			start := time.Now()
+			resp, err := next(req)
+			if err != nil {
+				logger.DebugContext(ctx, "pingdom request failed",
+					"method", req.Method,
+					"url", RedactURL(req.URL),
+					"duration", time.Since(start),
+					"error", RedactError(err),
+				)
+				return resp, err
+			}
+
+			logger.DebugContext(ctx, "pingdom response",
+				"method", req.Method,
+				"url", RedactURL(req.URL),
+				"status", resp.StatusCode,
+				"duration", time.Since(start),
+				"body", responseBody(resp),
+			)
+			return resp, nil
+		}
+	}
+}
+
+// RedactURL returns u with credentials in the query string, such as the
+// auth parameter of an HTTP check, redacted.  URLs without credentials are
+// returned unchanged.
+func RedactURL(u *url.URL) string {
+	r := *u
+	query := r.Query()
+	changed := false
+	for _, param := range sensitiveParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, Redacted)
+			changed = true
+		}
+	}
+	if changed {
+		r.RawQuery = query.Encode()
+	}
+	return r.String()
+}
+
+// RedactError returns err with credentials redacted from the URL of a
+// *url.Error, as returned by an http.Client when a request fails.  If the
+// *url.Error is wrapped by another error, only the redacted message is kept.
+// Other errors are returned unchanged.
+func RedactError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	redactedURL := Redacted
+	if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		redactedURL = RedactURL(u)
+	}
+	if err == error(urlErr) {
+		return &url.Error{Op: urlErr.Op, URL: redactedURL, Err: urlErr.Err}
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), urlErr.URL, redactedURL))
+}
+
+// RedactHeaders returns a copy of h with credentials, such as the
+// Authorization header, redacted.
+func RedactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// requestBody returns the redacted body of req without consuming it.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	b, _ := ioutil.ReadAll(body)
+	return RedactBody(b)
+}
+
+// responseBody returns the redacted body of resp, replacing the body so it
+// can still be read by the caller.
+func responseBody(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+	b, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return RedactBody(b)
+}
+
+// RedactBody redacts credentials, such as the password of an HTTP check,
+// from a JSON body.  Bodies without credentials and bodies that are not JSON
+// are returned unchanged.
+func RedactBody(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return string(b)
+	}
+	if !redactJSON(v) {
+		return string(b)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(b)
+	}
+	return string(out)
+}
+
+// redactJSON redacts credentials in v and reports whether it found any.
+func redactJSON(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveKey(key) {
+				v[key] = Redacted
+				changed = true
+				continue
+			}
+			changed = redactJSON(value) || changed
+		}
+	case []interface{}:
+		for _, value := range v {
+			changed = redactJSON(value) || changed
+		}
+	}
+	return changed
+}
+
+func isSensitiveKey(key string) bool {
+	for _, k := range sensitiveKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
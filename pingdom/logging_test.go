@@ -0,0 +1,148 @@
+//go:build go1.21
+
+package pingdom
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupLogging(t *testing.T, handler http.HandlerFunc) (*Client, *bytes.Buffer) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "secret_api_token",
+		BaseURL:  server.URL,
+		Logger:   logger,
+	})
+	assert.NoError(t, err)
+	return c, buf
+}
+
+func TestLoggingRedactsCheckCredentials(t *testing.T) {
+	c, buf := setupLogging(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret_api_token", r.Header.Get("Authorization"))
+		assert.Equal(t, "admin:hunter2", r.URL.Query().Get("auth"))
+		fmt.Fprint(w, `{"check": {"id": 138631, "name": "My new HTTP check"}}`)
+	})
+
+	check, err := c.Checks.Create(&HttpCheck{
+		Name:       "My new HTTP check",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Username:   "admin",
+		Password:   "hunter2",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &CheckResponse{ID: 138631, Name: "My new HTTP check"}, check)
+
+	out := buf.String()
+	assert.Contains(t, out, "pingdom request")
+	assert.Contains(t, out, "pingdom response")
+	assert.Contains(t, out, "auth=REDACTED")
+	assert.Contains(t, out, "Authorization:[REDACTED]")
+	assert.NotContains(t, out, "secret_api_token")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "admin:")
+}
+
+func TestLoggingRedactsResponsePassword(t *testing.T) {
+	c, buf := setupLogging(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 85975, "name": "My check", "type": {"http": {"url": "/", "username": "admin", "password": "hunter2"}}}}`)
+	})
+
+	check, err := c.Checks.Read(85975)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", check.Type.HTTP.Password)
+
+	out := buf.String()
+	assert.Contains(t, out, "status=200")
+	assert.Contains(t, out, `\"password\":\"REDACTED\"`)
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "secret_api_token")
+}
+
+func TestLoggingJSONRequestBody(t *testing.T) {
+	c, buf := setupLogging(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"team": {"id": 1, "name": "Operators"}}`)
+	})
+
+	_, err := c.Teams.Create(&Team{Name: "Operators"})
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `Operators`)
+	assert.Contains(t, out, "Content-Type:[application/json]")
+	assert.NotContains(t, out, "secret_api_token")
+}
+
+func TestLoggingRequestFailed(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "secret_api_token",
+		BaseURL:  "http://127.0.0.1:0",
+		Logger:   slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.List()
+	assert.Error(t, err)
+
+	_, err = c.Checks.Create(&HttpCheck{
+		Name:       "My new HTTP check",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Username:   "admin",
+		Password:   "hunter2",
+	})
+	assert.Error(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "pingdom request failed")
+	assert.Contains(t, out, "auth=REDACTED")
+	assert.NotContains(t, out, "secret_api_token")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "admin%3A")
+}
+
+func TestRedactError(t *testing.T) {
+	err := &url.Error{Op: "Post", URL: "http://127.0.0.1:0/checks?auth=admin%3Ahunter2&name=web", Err: errors.New("connection refused")}
+	assert.EqualError(t, RedactError(err), `Post "http://127.0.0.1:0/checks?auth=REDACTED&name=web": connection refused`)
+
+	wrapped := fmt.Errorf("retry failed: %w", err)
+	assert.EqualError(t, RedactError(wrapped), `retry failed: Post "http://127.0.0.1:0/checks?auth=REDACTED&name=web": connection refused`)
+
+	other := errors.New("other")
+	assert.Equal(t, other, RedactError(other))
+}
+
+func TestLoggingDisabledAboveDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": []}`)
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "secret_api_token",
+		BaseURL:  server.URL,
+		Logger:   slog.New(slog.NewTextHandler(buf, nil)),
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.List()
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
@@ -24,6 +24,20 @@ type MaintenanceDelete interface {
 	ValidDelete() error
 }
 
+// MaintenanceAPI is the set of operations provided by MaintenanceService.
+// Code that accepts a MaintenanceAPI rather than a *MaintenanceService can
+// be tested with a mock.
+type MaintenanceAPI interface {
+	List(params ...map[string]string) ([]MaintenanceResponse, error)
+	Read(id int) (*MaintenanceResponse, error)
+	Create(maintenance Maintenance) (*MaintenanceResponse, error)
+	Update(id int, maintenance Maintenance) (*PingdomResponse, error)
+	MultiDelete(maintenance MaintenanceDelete) (*PingdomResponse, error)
+	Delete(id int) (*PingdomResponse, error)
+}
+
+var _ MaintenanceAPI = (*MaintenanceService)(nil)
+
 // List returns the response holding a list of Maintenance windows.
 func (cs *MaintenanceService) List(params ...map[string]string) ([]MaintenanceResponse, error) {
 	param := map[string]string{}
@@ -39,7 +53,7 @@ func (cs *MaintenanceService) List(params ...map[string]string) ([]MaintenanceRe
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+package pingdom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RequestHandler sends an HTTP request to the Pingdom API and returns its
+// response.
+type RequestHandler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RequestHandler to observe or modify requests and
+// responses, e.g. for logging, metrics, tracing or injecting headers.  A
+// middleware should call next to send the request; returning without doing so
+// short-circuits the request.
+//
+// Middleware is configured with ClientConfig.Middleware:
+//
+//	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+//		Middleware: []pingdom.Middleware{
+//			func(next pingdom.RequestHandler) pingdom.RequestHandler {
+//				return func(req *http.Request) (*http.Response, error) {
+//					req.Header.Set("X-Request-Source", "inventory")
+//					return next(req)
+//				}
+//			},
+//		},
+//	})
+type Middleware func(next RequestHandler) RequestHandler
+
+// chain wraps h with middleware so that the first middleware is the
+// outermost.
+func chain(h RequestHandler, middleware []Middleware) RequestHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// do sends req through the middleware chain.
+func (pc *Client) do(req *http.Request) (*http.Response, error) {
+	if pc.handler == nil {
+		return pc.client.Do(req)
+	}
+	return pc.handler(req)
+}
+
+type resourceKey struct{}
+
+// withResource records the resource a request was made for so middleware can
+// report it with Endpoint.
+func withResource(req *http.Request, rsc string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), resourceKey{}, rsc))
+}
+
+// Endpoint returns the API endpoint of a request made by the client, with
+// numeric IDs replaced by placeholders, e.g. "/checks/{id}".  It is intended
+// for naming requests in logs, traces and metrics without creating a distinct
+// name per resource.  Requests not created with NewRequest or NewJSONRequest
+// are named after their URL path.
+func Endpoint(req *http.Request) string {
+	rsc, ok := req.Context().Value(resourceKey{}).(string)
+	if !ok {
+		rsc = req.URL.Path
+	}
+	if i := strings.IndexByte(rsc, '?'); i >= 0 {
+		rsc = rsc[:i]
+	}
+
+	segments := strings.Split(rsc, "/")
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// ResponseError returns the error reported in the body of a failed API
+// response, or nil if the response was successful or the body does not
+// contain a Pingdom error.  The body of resp can still be read afterwards.
+func ResponseError(resp *http.Response) *PingdomError {
+	if c := resp.StatusCode; 200 <= c && c <= 299 || resp.Body == nil {
+		return nil
+	}
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil
+	}
+
+	m := &errorJSONResponse{}
+	if err := json.Unmarshal(bodyBytes, m); err != nil {
+		return nil
+	}
+	return m.Error
+}
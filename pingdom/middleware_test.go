@@ -0,0 +1,154 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "outer,inner", r.Header.Get("X-Order"))
+		fmt.Fprint(w, `{"probes": []}`)
+	}))
+	defer server.Close()
+
+	var calls []string
+	mark := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(req *http.Request) (*http.Response, error) {
+				if v := req.Header.Get("X-Order"); v != "" {
+					req.Header.Set("X-Order", v+","+name)
+				} else {
+					req.Header.Set("X-Order", name)
+				}
+				calls = append(calls, name)
+				resp, err := next(req)
+				calls = append(calls, name+" done")
+				return resp, err
+			}
+		}
+	}
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken:   "my_api_key",
+		BaseURL:    server.URL,
+		Middleware: []Middleware{mark("outer"), mark("inner")},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Probes.List(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "inner done", "outer done"}, calls)
+}
+
+func TestMiddlewareWrapsDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 1}}`)
+	}))
+	defer server.Close()
+
+	var paths []string
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "my_api_key",
+		BaseURL:  server.URL,
+		Middleware: []Middleware{func(next RequestHandler) RequestHandler {
+			return func(req *http.Request) (*http.Response, error) {
+				paths = append(paths, req.URL.Path)
+				return next(req)
+			}
+		}},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/checks/1"}, paths)
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	injected := errors.New("injected failure")
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "my_api_key",
+		BaseURL:  "http://127.0.0.1:0",
+		Middleware: []Middleware{func(next RequestHandler) RequestHandler {
+			return func(req *http.Request) (*http.Response, error) {
+				return nil, injected
+			}
+		}},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.List()
+	assert.Equal(t, injected, err)
+
+	_, err = c.Checks.Read(1)
+	assert.Equal(t, injected, err)
+}
+
+func TestEndpoint(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "my_api_key"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		rsc  string
+		want string
+	}{
+		{rsc: "/checks", want: "/checks"},
+		{rsc: "/checks/12345", want: "/checks/{id}"},
+		{rsc: "/alerting/contacts/42", want: "/alerting/contacts/{id}"},
+		{rsc: "/results/12345?limit=1", want: "/results/{id}"},
+	}
+	for _, tt := range tests {
+		req, err := c.NewRequest("GET", tt.rsc, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, Endpoint(req))
+	}
+
+	req, err := c.NewJSONRequest("PUT", "/alerting/teams/7", "{}")
+	assert.NoError(t, err)
+	assert.Equal(t, "/alerting/teams/{id}", Endpoint(req))
+
+	req = httptest.NewRequest("GET", "/api/3.1/checks/9", nil)
+	assert.Equal(t, "/api/3.1/checks/{id}", Endpoint(req))
+}
+
+func TestResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/checks/1" {
+			fmt.Fprint(w, `{"check": {"id": 1}}`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"statuscode": 403, "statusdesc": "Forbidden", "errormessage": "Something went wrong!"}}`)
+	}))
+	defer server.Close()
+
+	var got []*PingdomError
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "my_api_key",
+		BaseURL:  server.URL,
+		Middleware: []Middleware{func(next RequestHandler) RequestHandler {
+			return func(req *http.Request) (*http.Response, error) {
+				resp, err := next(req)
+				if err == nil {
+					got = append(got, ResponseError(resp))
+				}
+				return resp, err
+			}
+		}},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.Read(1)
+	assert.NoError(t, err)
+
+	_, err = c.Checks.Read(2)
+	assert.Equal(t, &PingdomError{StatusCode: 403, StatusDesc: "Forbidden", Message: "Something went wrong!"}, err)
+
+	assert.Equal(t, []*PingdomError{nil, {StatusCode: 403, StatusDesc: "Forbidden", Message: "Something went wrong!"}}, got)
+}
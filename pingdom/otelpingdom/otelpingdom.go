@@ -0,0 +1,133 @@
+/*
+Package otelpingdom traces Pingdom API calls with OpenTelemetry.
+
+Add the middleware to the client configuration to create a client span for
+every request the client sends:
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "pingdom_api_token",
+		Middleware: []pingdom.Middleware{otelpingdom.Middleware()},
+	})
+
+Spans are named after the method and endpoint template, e.g.
+"GET /checks/{id}", and record the HTTP status code and the error code
+reported by Pingdom.  The full URL is not recorded because the API takes
+parameters such as check credentials in the query string, and these are
+redacted from the errors of failed requests.
+*/
+package otelpingdom
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer.
+const ScopeName = "github.com/russellcardullo/go-pingdom/pingdom/otelpingdom"
+
+// Attributes recorded for Pingdom errors, in addition to the standard HTTP
+// attributes.
+const (
+	ErrorCodeKey = attribute.Key("pingdom.error.code")
+	ErrorDescKey = attribute.Key("pingdom.error.description")
+)
+
+// Option configures the middleware.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+}
+
+// WithTracerProvider sets the TracerProvider used to create spans.  The
+// global TracerProvider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// Middleware returns a pingdom.Middleware that creates a span for each
+// request.  The span is the parent of any spans created further down the
+// chain, so for the retry count to be recorded this middleware should come
+// before any middleware that retries requests.
+func Middleware(opts ...Option) pingdom.Middleware {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tracerProvider == nil {
+		c.tracerProvider = otel.GetTracerProvider()
+	}
+	tracer := c.tracerProvider.Tracer(ScopeName)
+
+	return func(next pingdom.RequestHandler) pingdom.RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			endpoint := pingdom.Endpoint(req)
+			retries := &retryCounter{}
+			ctx := context.WithValue(req.Context(), retryKey{}, retries)
+			ctx, span := tracer.Start(ctx, req.Method+" "+endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.URLTemplate(endpoint),
+					semconv.ServerAddress(req.URL.Hostname()),
+				),
+			)
+			defer span.End()
+
+			resp, err := next(req.WithContext(ctx))
+
+			if n := atomic.LoadInt64(&retries.n); n > 0 {
+				span.SetAttributes(semconv.HTTPRequestResendCount(int(n)))
+			}
+			if err != nil {
+				// The error of a failed request contains the full URL.
+				redacted := pingdom.RedactError(err)
+				span.RecordError(redacted)
+				span.SetStatus(codes.Error, redacted.Error())
+				return resp, err
+			}
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+				desc := http.StatusText(resp.StatusCode)
+				if perr := pingdom.ResponseError(resp); perr != nil {
+					span.SetAttributes(
+						ErrorCodeKey.Int(perr.StatusCode),
+						ErrorDescKey.String(perr.StatusDesc),
+					)
+					desc = perr.Error()
+				}
+				span.SetStatus(codes.Error, desc)
+			}
+			return resp, nil
+		}
+	}
+}
+
+type retryKey struct{}
+
+type retryCounter struct {
+	n int64
+}
+
+// RecordRetry records that the request with the given context is being
+// retried.  Middleware that retries requests should call it with the
+// context of the request before each retry so the retry count is added to
+// the span.  It does nothing if the request is not traced.
+func RecordRetry(ctx context.Context) {
+	if c, ok := ctx.Value(retryKey{}).(*retryCounter); ok {
+		atomic.AddInt64(&c.n, 1)
+	}
+}
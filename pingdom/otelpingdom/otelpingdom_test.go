@@ -0,0 +1,193 @@
+package otelpingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc, middleware ...pingdom.Middleware) (*pingdom.Client, *tracetest.InMemoryExporter) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "my_api_key",
+		BaseURL:    server.URL,
+		Middleware: append([]pingdom.Middleware{Middleware(WithTracerProvider(tp))}, middleware...),
+	})
+	assert.NoError(t, err)
+	return client, exporter
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestMiddleware(t *testing.T) {
+	client, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 12345, "name": "Example"}}`)
+	})
+
+	_, err := client.Checks.Read(12345)
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, "GET /checks/{id}", span.Name)
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+		assert.Equal(t, codes.Unset, span.Status.Code)
+
+		attrs := attributes(span)
+		assert.Equal(t, "GET", attrs["http.request.method"].AsString())
+		assert.Equal(t, "/checks/{id}", attrs["url.template"].AsString())
+		assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+		assert.NotContains(t, attrs, attribute.Key("http.request.resend_count"))
+	}
+}
+
+func TestMiddlewareListMethods(t *testing.T) {
+	client, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	_, err := client.Checks.List()
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "GET /checks", spans[0].Name)
+	}
+}
+
+func TestMiddlewarePingdomError(t *testing.T) {
+	client, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"statuscode": 403, "statusdesc": "Forbidden", "errormessage": "Something went wrong!"}}`)
+	})
+
+	_, err := client.Checks.Delete(12345)
+	assert.Equal(t, &pingdom.PingdomError{StatusCode: 403, StatusDesc: "Forbidden", Message: "Something went wrong!"}, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, "DELETE /checks/{id}", span.Name)
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Equal(t, "403 Forbidden: Something went wrong!", span.Status.Description)
+
+		attrs := attributes(span)
+		assert.Equal(t, int64(403), attrs["http.response.status_code"].AsInt64())
+		assert.Equal(t, int64(403), attrs[ErrorCodeKey].AsInt64())
+		assert.Equal(t, "Forbidden", attrs[ErrorDescKey].AsString())
+	}
+}
+
+func TestMiddlewareRetries(t *testing.T) {
+	attempts := 0
+	retry := func(next pingdom.RequestHandler) pingdom.RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			for {
+				resp, err := next(req)
+				if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+					return resp, err
+				}
+				resp.Body.Close()
+				RecordRetry(req.Context())
+			}
+		}
+	}
+	client, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"probes": []}`)
+	}, retry)
+
+	_, err := client.Probes.List(nil)
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		attrs := attributes(spans[0])
+		assert.Equal(t, int64(2), attrs["http.request.resend_count"].AsInt64())
+		assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+	}
+}
+
+func TestMiddlewareTransportError(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "my_api_key",
+		BaseURL:    "http://127.0.0.1:0",
+		Middleware: []pingdom.Middleware{Middleware(WithTracerProvider(tp))},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Checks.Read(1)
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Len(t, spans[0].Events, 1)
+	}
+}
+
+func TestMiddlewareTransportErrorRedactsCredentials(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "my_api_key",
+		BaseURL:    "http://127.0.0.1:0",
+		Middleware: []pingdom.Middleware{Middleware(WithTracerProvider(tp))},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Checks.Create(&pingdom.HttpCheck{
+		Name:       "Example",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Username:   "admin",
+		Password:   "hunter2",
+	})
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Contains(t, span.Status.Description, "auth=REDACTED")
+		assert.NotContains(t, span.Status.Description, "hunter2")
+		if assert.Len(t, span.Events, 1) {
+			for _, kv := range span.Events[0].Attributes {
+				assert.NotContains(t, kv.Value.Emit(), "hunter2")
+			}
+		}
+	}
+}
+
+func TestRecordRetryWithoutSpan(t *testing.T) {
+	assert.NotPanics(t, func() {
+		RecordRetry(httptest.NewRequest("GET", "/", nil).Context())
+	})
+}
@@ -0,0 +1,231 @@
+/*
+Package outage reconstructs outage intervals from the raw results of a
+Pingdom check.
+
+	it := results.NewIterator(client.Checks, 12345, from, to, nil)
+	var rs []pingdom.Result
+	for it.Next() {
+		rs = append(rs, it.Page().Results...)
+	}
+	for _, o := range outage.Reconstruct(rs, outage.Rule{ConfirmDown: 2, MinProbes: 2}) {
+		log.Printf("%s for %s: %s", o.Start, o.Duration(), o.StatusDesc)
+	}
+
+Results with status "down" or "unconfirmed" count as failures and results
+with status "up" as successes.  An unconfirmed result is a failure reported
+by a single probe before Pingdom verified it, so it counts toward
+Rule.ConfirmDown like any other failure.  Other results, such as those with
+status "unknown", are ignored.  A Rule sets how many results confirm that
+the check went down or recovered, so that a single failing probe or a
+flapping check does not produce a series of short outages.
+*/
+package outage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Rule decides when results confirm a change of state.  The zero value
+// opens an outage at the first failure and closes it at the first success.
+type Rule struct {
+	// ConfirmDown is the number of consecutive failures, from any probes,
+	// needed to open an outage.  The outage starts at the first of them.
+	ConfirmDown int
+
+	// MinProbes is the number of distinct probes among these failures
+	// needed to open an outage.
+	MinProbes int
+
+	// ConfirmUp is the number of consecutive successes needed to close an
+	// outage.  The outage ends at the first of them.
+	ConfirmUp int
+
+	// MergeGap merges outages separated by at most this duration.
+	MergeGap time.Duration
+}
+
+// Outage is an interval during which the check was down.
+type Outage struct {
+	Start time.Time
+
+	// End is the time of the first success that closed the outage, or the
+	// time of the last result if the outage is ongoing.
+	End     time.Time
+	Ongoing bool
+
+	// Probes are the IDs of the probes that reported failures during the
+	// outage, in ascending order.
+	Probes []int
+
+	// Failures is the number of failed results during the outage.
+	Failures int
+
+	// StatusDesc and StatusDescLong describe the first failure.
+	StatusDesc     string
+	StatusDescLong string
+}
+
+// Duration returns the length of the outage.
+func (o Outage) Duration() time.Duration {
+	return o.End.Sub(o.Start)
+}
+
+const (
+	ignored = iota
+	success
+	failure
+)
+
+func classify(r pingdom.Result) int {
+	switch r.Status {
+	case "up":
+		return success
+	case "down", "unconfirmed":
+		return failure
+	}
+	return ignored
+}
+
+// Reconstruct returns the outages in results, oldest first.  The results
+// may be in any order and from any number of probes.
+func Reconstruct(results []pingdom.Result, rule Rule) []Outage {
+	if rule.ConfirmDown < 1 {
+		rule.ConfirmDown = 1
+	}
+	if rule.MinProbes < 1 {
+		rule.MinProbes = 1
+	}
+	if rule.ConfirmUp < 1 {
+		rule.ConfirmUp = 1
+	}
+
+	rs := append([]pingdom.Result{}, results...)
+	sort.SliceStable(rs, func(i, j int) bool { return rs[i].Time < rs[j].Time })
+
+	var outages []Outage
+	var current *outage
+	var failures []pingdom.Result
+	successes := 0
+	var recovered time.Time
+	var last time.Time
+
+	for _, r := range rs {
+		kind := classify(r)
+		if kind == ignored {
+			continue
+		}
+		t := unix(r.Time)
+		last = t
+
+		if current == nil {
+			if kind == success {
+				failures = nil
+				continue
+			}
+			failures = append(failures, r)
+			if len(failures) >= rule.ConfirmDown && len(probes(failures)) >= rule.MinProbes {
+				current = newOutage(failures)
+				failures = nil
+			}
+			continue
+		}
+
+		if kind == failure {
+			successes = 0
+			current.add(r)
+			continue
+		}
+		if successes == 0 {
+			recovered = t
+		}
+		successes++
+		if successes >= rule.ConfirmUp {
+			outages = append(outages, current.finish(recovered, false))
+			current = nil
+			successes = 0
+		}
+	}
+	if current != nil {
+		outages = append(outages, current.finish(last, true))
+	}
+
+	return merge(outages, rule.MergeGap)
+}
+
+// outage is an outage being reconstructed.
+type outage struct {
+	Outage
+	probes map[int]bool
+}
+
+func newOutage(failures []pingdom.Result) *outage {
+	o := &outage{
+		Outage: Outage{
+			Start:          unix(failures[0].Time),
+			StatusDesc:     failures[0].StatusDesc,
+			StatusDescLong: failures[0].StatusDescLong,
+		},
+		probes: map[int]bool{},
+	}
+	for _, r := range failures {
+		o.add(r)
+	}
+	return o
+}
+
+func (o *outage) add(r pingdom.Result) {
+	o.Failures++
+	o.probes[r.ProbeID] = true
+}
+
+func (o *outage) finish(end time.Time, ongoing bool) Outage {
+	o.End = end
+	o.Ongoing = ongoing
+	o.Probes = sortedKeys(o.probes)
+	return o.Outage
+}
+
+// merge merges consecutive outages separated by at most gap.
+func merge(outages []Outage, gap time.Duration) []Outage {
+	var merged []Outage
+	for _, o := range outages {
+		if n := len(merged); n > 0 && o.Start.Sub(merged[n-1].End) <= gap {
+			prev := &merged[n-1]
+			probes := map[int]bool{}
+			for _, p := range append(prev.Probes, o.Probes...) {
+				probes[p] = true
+			}
+			prev.End = o.End
+			prev.Ongoing = o.Ongoing
+			prev.Probes = sortedKeys(probes)
+			prev.Failures += o.Failures
+			continue
+		}
+		merged = append(merged, o)
+	}
+	return merged
+}
+
+func probes(rs []pingdom.Result) map[int]bool {
+	ps := map[int]bool{}
+	for _, r := range rs {
+		ps[r.ProbeID] = true
+	}
+	return ps
+}
+
+func sortedKeys(m map[int]bool) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func unix(t int) time.Time {
+	return time.Unix(int64(t), 0).UTC()
+}
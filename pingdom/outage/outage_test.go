@@ -0,0 +1,124 @@
+package outage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequence returns a result every minute from time 0 for each character of
+// s: "u" for up, "d" for down, "c" for unconfirmed and "?" for unknown.
+// Probes are assigned in turn from probes.
+func sequence(s string, probes ...int) []pingdom.Result {
+	statuses := map[rune]string{'u': "up", 'd': "down", 'c': "unconfirmed", '?': "unknown"}
+	var rs []pingdom.Result
+	for i, c := range s {
+		r := pingdom.Result{ProbeID: probes[i%len(probes)], Time: 60 * i, Status: statuses[c]}
+		if c == 'd' || c == 'c' {
+			r.StatusDesc = "Timeout"
+			r.StatusDescLong = "Timeout at minute " + strings.Repeat("I", i)
+		}
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func minute(n int) time.Time {
+	return time.Unix(int64(60*n), 0).UTC()
+}
+
+func intervals(outages []Outage) [][2]int {
+	var got [][2]int
+	for _, o := range outages {
+		got = append(got, [2]int{int(o.Start.Unix() / 60), int(o.End.Unix() / 60)})
+	}
+	return got
+}
+
+func TestReconstruct(t *testing.T) {
+	outages := Reconstruct(sequence("uuddduuduu", 1, 2), Rule{})
+
+	assert.Equal(t, [][2]int{{2, 5}, {7, 8}}, intervals(outages))
+	first := outages[0]
+	assert.Equal(t, minute(2), first.Start)
+	assert.Equal(t, minute(5), first.End)
+	assert.Equal(t, 3*time.Minute, first.Duration())
+	assert.False(t, first.Ongoing)
+	assert.Equal(t, []int{1, 2}, first.Probes)
+	assert.Equal(t, 3, first.Failures)
+	assert.Equal(t, "Timeout", first.StatusDesc)
+	assert.Equal(t, "Timeout at minute II", first.StatusDescLong)
+}
+
+func TestReconstruct_ConfirmDown(t *testing.T) {
+	rs := sequence("uduuddduudu", 1)
+	assert.Equal(t, [][2]int{{4, 7}}, intervals(Reconstruct(rs, Rule{ConfirmDown: 2})))
+	assert.Equal(t, [][2]int{{4, 7}}, intervals(Reconstruct(rs, Rule{ConfirmDown: 3})))
+	assert.Empty(t, Reconstruct(rs, Rule{ConfirmDown: 4}))
+}
+
+func TestReconstruct_Unconfirmed(t *testing.T) {
+	rs := sequence("uccduu", 1, 2)
+
+	outages := Reconstruct(rs, Rule{ConfirmDown: 3, MinProbes: 2})
+
+	assert.Equal(t, [][2]int{{1, 4}}, intervals(outages))
+	assert.Equal(t, 3, outages[0].Failures)
+	assert.Equal(t, []int{1, 2}, outages[0].Probes)
+	assert.Empty(t, Reconstruct(sequence("ucuuuu", 1), Rule{ConfirmDown: 2}))
+}
+
+func TestReconstruct_MinProbes(t *testing.T) {
+	rs := append(sequence("uddu", 1), pingdom.Result{ProbeID: 2, Time: 600, Status: "down"}, pingdom.Result{ProbeID: 3, Time: 660, Status: "down"}, pingdom.Result{ProbeID: 3, Time: 720, Status: "up"})
+
+	outages := Reconstruct(rs, Rule{MinProbes: 2})
+
+	assert.Equal(t, [][2]int{{10, 12}}, intervals(outages))
+	assert.Equal(t, []int{2, 3}, outages[0].Probes)
+}
+
+func TestReconstruct_ConfirmUp(t *testing.T) {
+	rs := sequence("udududuuud", 1)
+
+	assert.Equal(t, [][2]int{{1, 6}, {9, 9}}, intervals(Reconstruct(rs, Rule{ConfirmUp: 2})))
+	assert.Equal(t, [][2]int{{1, 2}, {3, 4}, {5, 6}, {9, 9}}, intervals(Reconstruct(rs, Rule{})))
+}
+
+func TestReconstruct_MergeGap(t *testing.T) {
+	rs := sequence("udduudduuuudu", 1)
+
+	outages := Reconstruct(rs, Rule{MergeGap: 2 * time.Minute})
+
+	assert.Equal(t, [][2]int{{1, 7}, {11, 12}}, intervals(outages))
+	assert.Equal(t, 4, outages[0].Failures)
+}
+
+func TestReconstruct_Ongoing(t *testing.T) {
+	outages := Reconstruct(sequence("uuddd", 1), Rule{})
+
+	assert.Len(t, outages, 1)
+	assert.True(t, outages[0].Ongoing)
+	assert.Equal(t, minute(4), outages[0].End)
+}
+
+func TestReconstruct_UnorderedAndIgnored(t *testing.T) {
+	rs := sequence("uc?dd?uu", 1)
+	reversed := make([]pingdom.Result, len(rs))
+	for i, r := range rs {
+		reversed[len(rs)-1-i] = r
+	}
+
+	outages := Reconstruct(reversed, Rule{ConfirmDown: 3})
+
+	assert.Equal(t, [][2]int{{1, 6}}, intervals(outages))
+	assert.Equal(t, 3, outages[0].Failures)
+	assert.Equal(t, 6, reversed[1].Time/60, "Reconstruct should not reorder its argument")
+}
+
+func TestReconstruct_Empty(t *testing.T) {
+	assert.Empty(t, Reconstruct(nil, Rule{}))
+	assert.Empty(t, Reconstruct(sequence("uuu??", 1), Rule{}))
+}
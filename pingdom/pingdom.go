@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 )
 
 const (
@@ -16,14 +17,21 @@ const (
 
 // Client represents a client to the Pingdom API.
 type Client struct {
+	// APIToken is sent with requests when the client has no TokenSource.
+	// It must not be changed while requests are being made; use
+	// SetTokenSource to rotate the token of a running client.
 	APIToken     string
 	BaseURL      *url.URL
 	client       *http.Client
+	handler      RequestHandler
 	Checks       *CheckService
 	Contacts     *ContactService
 	Maintenances *MaintenanceService
 	Probes       *ProbeService
 	Teams        *TeamService
+
+	tokenMu     sync.RWMutex
+	tokenSource TokenSource
 }
 
 // ClientConfig represents a configuration for a pingdom client.
@@ -31,6 +39,18 @@ type ClientConfig struct {
 	APIToken   string
 	BaseURL    string
 	HTTPClient *http.Client
+
+	// TokenSource, if set, supplies the API token of every request
+	// instead of APIToken and the PINGDOM_API_TOKEN environment variable.
+	TokenSource TokenSource
+
+	// Middleware wraps every request sent by the client, including those
+	// made by the list methods.  The first middleware is the outermost.
+	Middleware []Middleware
+
+	// Logger, if set, logs every request and response at debug level with
+	// credentials redacted.  A *slog.Logger can be used directly.
+	Logger Logger
 }
 
 // NewClientWithConfig returns a Pingdom client.
@@ -47,7 +67,8 @@ func NewClientWithConfig(config ClientConfig) (*Client, error) {
 	}
 
 	c := &Client{
-		BaseURL: baseURL,
+		BaseURL:     baseURL,
+		tokenSource: config.TokenSource,
 	}
 
 	if config.APIToken == "" {
@@ -63,6 +84,11 @@ func NewClientWithConfig(config ClientConfig) (*Client, error) {
 	} else {
 		c.client = http.DefaultClient
 	}
+	middleware := append([]Middleware{}, config.Middleware...)
+	if config.Logger != nil {
+		middleware = append(middleware, logRequests(config.Logger))
+	}
+	c.handler = chain(c.client.Do, middleware)
 
 	c.Checks = &CheckService{client: c}
 	c.Contacts = &ContactService{client: c}
@@ -93,8 +119,16 @@ func (pc *Client) NewRequest(method string, rsc string, params map[string]string
 	}
 
 	req, err := http.NewRequest(method, baseURL.String(), nil)
-	req.Header.Add("Authorization", "Bearer "+pc.APIToken)
-	return req, err
+	if err != nil {
+		return nil, err
+	}
+	token, err := pc.token()
+	if err != nil {
+		return nil, err
+	}
+	req = withResource(req, rsc)
+	req.Header.Add("Authorization", "Bearer "+token)
+	return req, nil
 }
 
 // NewJSONRequest makes a new HTTP Request.  The method param should be an HTTP method in
@@ -109,16 +143,24 @@ func (pc *Client) NewJSONRequest(method string, rsc string, params string) (*htt
 	reqBody := strings.NewReader(params)
 
 	req, err := http.NewRequest(method, baseURL.String(), reqBody)
-	req.Header.Add("Authorization", "Bearer "+pc.APIToken)
+	if err != nil {
+		return nil, err
+	}
+	token, err := pc.token()
+	if err != nil {
+		return nil, err
+	}
+	req = withResource(req, rsc)
+	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("Content-Type", "application/json")
-	return req, err
+	return req, nil
 }
 
 // Do makes an HTTP request and will unmarshal the JSON response in to the
 // passed in interface.  If the HTTP response is outside of the 2xx range the
 // response will be returned along with the error.
 func (pc *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := pc.client.Do(req)
+	resp, err := pc.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,131 @@
+package pingdommock
+
+import "github.com/russellcardullo/go-pingdom/pingdom"
+
+// CheckAPI is a mock implementation of pingdom.CheckAPI.
+type CheckAPI struct {
+	calls
+
+	ListFunc               func(params ...map[string]string) ([]pingdom.CheckResponse, error)
+	CreateFunc             func(check pingdom.Check) (*pingdom.CheckResponse, error)
+	ReadFunc               func(id int) (*pingdom.CheckResponse, error)
+	ReadExpandedFunc       func(id int) (*pingdom.ExpandedCheck, error)
+	UpdateFunc             func(id int, check pingdom.Check) (*pingdom.PingdomResponse, error)
+	DeleteFunc             func(id int) (*pingdom.PingdomResponse, error)
+	SummaryPerformanceFunc func(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error)
+	ResultsFunc            func(id int, params ...map[string]string) (*pingdom.ResultsResponse, error)
+	ListByTagFunc          func(tags ...string) ([]pingdom.CheckResponse, error)
+	AddTagFunc             func(tag string, ids ...int) error
+	RemoveTagFunc          func(tag string, ids ...int) error
+	RenameTagFunc          func(from, to string) ([]int, error)
+}
+
+var _ pingdom.CheckAPI = (*CheckAPI)(nil)
+
+// List calls ListFunc.
+func (m *CheckAPI) List(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	m.record("List", params)
+	if m.ListFunc == nil {
+		return nil, notMocked("CheckAPI", "List")
+	}
+	return m.ListFunc(params...)
+}
+
+// Create calls CreateFunc.
+func (m *CheckAPI) Create(check pingdom.Check) (*pingdom.CheckResponse, error) {
+	m.record("Create", check)
+	if m.CreateFunc == nil {
+		return nil, notMocked("CheckAPI", "Create")
+	}
+	return m.CreateFunc(check)
+}
+
+// Read calls ReadFunc.
+func (m *CheckAPI) Read(id int) (*pingdom.CheckResponse, error) {
+	m.record("Read", id)
+	if m.ReadFunc == nil {
+		return nil, notMocked("CheckAPI", "Read")
+	}
+	return m.ReadFunc(id)
+}
+
+// ReadExpanded calls ReadExpandedFunc.
+func (m *CheckAPI) ReadExpanded(id int) (*pingdom.ExpandedCheck, error) {
+	m.record("ReadExpanded", id)
+	if m.ReadExpandedFunc == nil {
+		return nil, notMocked("CheckAPI", "ReadExpanded")
+	}
+	return m.ReadExpandedFunc(id)
+}
+
+// Update calls UpdateFunc.
+func (m *CheckAPI) Update(id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	m.record("Update", id, check)
+	if m.UpdateFunc == nil {
+		return nil, notMocked("CheckAPI", "Update")
+	}
+	return m.UpdateFunc(id, check)
+}
+
+// Delete calls DeleteFunc.
+func (m *CheckAPI) Delete(id int) (*pingdom.PingdomResponse, error) {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return nil, notMocked("CheckAPI", "Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// SummaryPerformance calls SummaryPerformanceFunc.
+func (m *CheckAPI) SummaryPerformance(request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+	m.record("SummaryPerformance", request)
+	if m.SummaryPerformanceFunc == nil {
+		return nil, notMocked("CheckAPI", "SummaryPerformance")
+	}
+	return m.SummaryPerformanceFunc(request)
+}
+
+// Results calls ResultsFunc.
+func (m *CheckAPI) Results(id int, params ...map[string]string) (*pingdom.ResultsResponse, error) {
+	m.record("Results", id, params)
+	if m.ResultsFunc == nil {
+		return nil, notMocked("CheckAPI", "Results")
+	}
+	return m.ResultsFunc(id, params...)
+}
+
+// ListByTag calls ListByTagFunc.
+func (m *CheckAPI) ListByTag(tags ...string) ([]pingdom.CheckResponse, error) {
+	m.record("ListByTag", tags)
+	if m.ListByTagFunc == nil {
+		return nil, notMocked("CheckAPI", "ListByTag")
+	}
+	return m.ListByTagFunc(tags...)
+}
+
+// AddTag calls AddTagFunc.
+func (m *CheckAPI) AddTag(tag string, ids ...int) error {
+	m.record("AddTag", tag, ids)
+	if m.AddTagFunc == nil {
+		return notMocked("CheckAPI", "AddTag")
+	}
+	return m.AddTagFunc(tag, ids...)
+}
+
+// RemoveTag calls RemoveTagFunc.
+func (m *CheckAPI) RemoveTag(tag string, ids ...int) error {
+	m.record("RemoveTag", tag, ids)
+	if m.RemoveTagFunc == nil {
+		return notMocked("CheckAPI", "RemoveTag")
+	}
+	return m.RemoveTagFunc(tag, ids...)
+}
+
+// RenameTag calls RenameTagFunc.
+func (m *CheckAPI) RenameTag(from, to string) ([]int, error) {
+	m.record("RenameTag", from, to)
+	if m.RenameTagFunc == nil {
+		return nil, notMocked("CheckAPI", "RenameTag")
+	}
+	return m.RenameTagFunc(from, to)
+}
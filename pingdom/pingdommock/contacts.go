@@ -0,0 +1,122 @@
+package pingdommock
+
+import "github.com/russellcardullo/go-pingdom/pingdom"
+
+// ContactServiceAPI is a mock implementation of pingdom.ContactServiceAPI.
+type ContactServiceAPI struct {
+	calls
+
+	ListFunc   func() ([]pingdom.Contact, error)
+	ReadFunc   func(contactID int) (*pingdom.Contact, error)
+	CreateFunc func(contact pingdom.ContactAPI) (*pingdom.Contact, error)
+	UpdateFunc func(id int, contact pingdom.ContactAPI) (*pingdom.PingdomResponse, error)
+	DeleteFunc func(id int) (*pingdom.PingdomResponse, error)
+
+	AddSMSTargetFunc       func(id int, target pingdom.SMSNotification) error
+	RemoveSMSTargetFunc    func(id int, target pingdom.SMSNotification) error
+	ReplaceSMSTargetFunc   func(id int, old, target pingdom.SMSNotification) error
+	AddEmailTargetFunc     func(id int, target pingdom.EmailNotification) error
+	RemoveEmailTargetFunc  func(id int, target pingdom.EmailNotification) error
+	ReplaceEmailTargetFunc func(id int, old, target pingdom.EmailNotification) error
+}
+
+var _ pingdom.ContactServiceAPI = (*ContactServiceAPI)(nil)
+
+// List calls ListFunc.
+func (m *ContactServiceAPI) List() ([]pingdom.Contact, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, notMocked("ContactServiceAPI", "List")
+	}
+	return m.ListFunc()
+}
+
+// Read calls ReadFunc.
+func (m *ContactServiceAPI) Read(contactID int) (*pingdom.Contact, error) {
+	m.record("Read", contactID)
+	if m.ReadFunc == nil {
+		return nil, notMocked("ContactServiceAPI", "Read")
+	}
+	return m.ReadFunc(contactID)
+}
+
+// Create calls CreateFunc.
+func (m *ContactServiceAPI) Create(contact pingdom.ContactAPI) (*pingdom.Contact, error) {
+	m.record("Create", contact)
+	if m.CreateFunc == nil {
+		return nil, notMocked("ContactServiceAPI", "Create")
+	}
+	return m.CreateFunc(contact)
+}
+
+// Update calls UpdateFunc.
+func (m *ContactServiceAPI) Update(id int, contact pingdom.ContactAPI) (*pingdom.PingdomResponse, error) {
+	m.record("Update", id, contact)
+	if m.UpdateFunc == nil {
+		return nil, notMocked("ContactServiceAPI", "Update")
+	}
+	return m.UpdateFunc(id, contact)
+}
+
+// Delete calls DeleteFunc.
+func (m *ContactServiceAPI) Delete(id int) (*pingdom.PingdomResponse, error) {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return nil, notMocked("ContactServiceAPI", "Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// AddSMSTarget calls AddSMSTargetFunc.
+func (m *ContactServiceAPI) AddSMSTarget(id int, target pingdom.SMSNotification) error {
+	m.record("AddSMSTarget", id, target)
+	if m.AddSMSTargetFunc == nil {
+		return notMocked("ContactServiceAPI", "AddSMSTarget")
+	}
+	return m.AddSMSTargetFunc(id, target)
+}
+
+// RemoveSMSTarget calls RemoveSMSTargetFunc.
+func (m *ContactServiceAPI) RemoveSMSTarget(id int, target pingdom.SMSNotification) error {
+	m.record("RemoveSMSTarget", id, target)
+	if m.RemoveSMSTargetFunc == nil {
+		return notMocked("ContactServiceAPI", "RemoveSMSTarget")
+	}
+	return m.RemoveSMSTargetFunc(id, target)
+}
+
+// ReplaceSMSTarget calls ReplaceSMSTargetFunc.
+func (m *ContactServiceAPI) ReplaceSMSTarget(id int, old, target pingdom.SMSNotification) error {
+	m.record("ReplaceSMSTarget", id, old, target)
+	if m.ReplaceSMSTargetFunc == nil {
+		return notMocked("ContactServiceAPI", "ReplaceSMSTarget")
+	}
+	return m.ReplaceSMSTargetFunc(id, old, target)
+}
+
+// AddEmailTarget calls AddEmailTargetFunc.
+func (m *ContactServiceAPI) AddEmailTarget(id int, target pingdom.EmailNotification) error {
+	m.record("AddEmailTarget", id, target)
+	if m.AddEmailTargetFunc == nil {
+		return notMocked("ContactServiceAPI", "AddEmailTarget")
+	}
+	return m.AddEmailTargetFunc(id, target)
+}
+
+// RemoveEmailTarget calls RemoveEmailTargetFunc.
+func (m *ContactServiceAPI) RemoveEmailTarget(id int, target pingdom.EmailNotification) error {
+	m.record("RemoveEmailTarget", id, target)
+	if m.RemoveEmailTargetFunc == nil {
+		return notMocked("ContactServiceAPI", "RemoveEmailTarget")
+	}
+	return m.RemoveEmailTargetFunc(id, target)
+}
+
+// ReplaceEmailTarget calls ReplaceEmailTargetFunc.
+func (m *ContactServiceAPI) ReplaceEmailTarget(id int, old, target pingdom.EmailNotification) error {
+	m.record("ReplaceEmailTarget", id, old, target)
+	if m.ReplaceEmailTargetFunc == nil {
+		return notMocked("ContactServiceAPI", "ReplaceEmailTarget")
+	}
+	return m.ReplaceEmailTargetFunc(id, old, target)
+}
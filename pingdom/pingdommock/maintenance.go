@@ -0,0 +1,71 @@
+package pingdommock
+
+import "github.com/russellcardullo/go-pingdom/pingdom"
+
+// MaintenanceAPI is a mock implementation of pingdom.MaintenanceAPI.
+type MaintenanceAPI struct {
+	calls
+
+	ListFunc        func(params ...map[string]string) ([]pingdom.MaintenanceResponse, error)
+	ReadFunc        func(id int) (*pingdom.MaintenanceResponse, error)
+	CreateFunc      func(maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error)
+	UpdateFunc      func(id int, maintenance pingdom.Maintenance) (*pingdom.PingdomResponse, error)
+	MultiDeleteFunc func(maintenance pingdom.MaintenanceDelete) (*pingdom.PingdomResponse, error)
+	DeleteFunc      func(id int) (*pingdom.PingdomResponse, error)
+}
+
+var _ pingdom.MaintenanceAPI = (*MaintenanceAPI)(nil)
+
+// List calls ListFunc.
+func (m *MaintenanceAPI) List(params ...map[string]string) ([]pingdom.MaintenanceResponse, error) {
+	m.record("List", params)
+	if m.ListFunc == nil {
+		return nil, notMocked("MaintenanceAPI", "List")
+	}
+	return m.ListFunc(params...)
+}
+
+// Read calls ReadFunc.
+func (m *MaintenanceAPI) Read(id int) (*pingdom.MaintenanceResponse, error) {
+	m.record("Read", id)
+	if m.ReadFunc == nil {
+		return nil, notMocked("MaintenanceAPI", "Read")
+	}
+	return m.ReadFunc(id)
+}
+
+// Create calls CreateFunc.
+func (m *MaintenanceAPI) Create(maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error) {
+	m.record("Create", maintenance)
+	if m.CreateFunc == nil {
+		return nil, notMocked("MaintenanceAPI", "Create")
+	}
+	return m.CreateFunc(maintenance)
+}
+
+// Update calls UpdateFunc.
+func (m *MaintenanceAPI) Update(id int, maintenance pingdom.Maintenance) (*pingdom.PingdomResponse, error) {
+	m.record("Update", id, maintenance)
+	if m.UpdateFunc == nil {
+		return nil, notMocked("MaintenanceAPI", "Update")
+	}
+	return m.UpdateFunc(id, maintenance)
+}
+
+// MultiDelete calls MultiDeleteFunc.
+func (m *MaintenanceAPI) MultiDelete(maintenance pingdom.MaintenanceDelete) (*pingdom.PingdomResponse, error) {
+	m.record("MultiDelete", maintenance)
+	if m.MultiDeleteFunc == nil {
+		return nil, notMocked("MaintenanceAPI", "MultiDelete")
+	}
+	return m.MultiDeleteFunc(maintenance)
+}
+
+// Delete calls DeleteFunc.
+func (m *MaintenanceAPI) Delete(id int) (*pingdom.PingdomResponse, error) {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return nil, notMocked("MaintenanceAPI", "Delete")
+	}
+	return m.DeleteFunc(id)
+}
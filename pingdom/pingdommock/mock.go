@@ -0,0 +1,58 @@
+/*
+Package pingdommock provides hand-written mocks of the pingdom service
+interfaces for use in tests.
+
+Each mock has a function field per method of the interface it implements.
+Calls are forwarded to that function and recorded, so tests can both stub
+responses and assert on how the code under test used the API:
+
+	checks := &pingdommock.CheckAPI{
+		ReadFunc: func(id int) (*pingdom.CheckResponse, error) {
+			return &pingdom.CheckResponse{ID: id, Status: "down"}, nil
+		},
+	}
+	err := codeUnderTest(checks)
+	calls := checks.Calls() // [{Read [12345]}]
+
+Calling a method whose function field is nil returns an error wrapping
+ErrNotMocked.
+*/
+package pingdommock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotMocked is returned by mock methods whose function field is not set.
+var ErrNotMocked = errors.New("method not mocked")
+
+// Call is a single recorded call of a mock method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// calls records the calls made to a mock.  It is safe for concurrent use.
+type calls struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (c *calls) record(method string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made to the mock so far, in order.
+func (c *calls) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+func notMocked(mock, method string) error {
+	return fmt.Errorf("pingdommock: %s.%s: %w", mock, method, ErrNotMocked)
+}
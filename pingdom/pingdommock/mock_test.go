@@ -0,0 +1,48 @@
+package pingdommock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockForwardsAndRecordsCalls(t *testing.T) {
+	checks := &CheckAPI{
+		ReadFunc: func(id int) (*pingdom.CheckResponse, error) {
+			return &pingdom.CheckResponse{ID: id, Status: "down"}, nil
+		},
+	}
+
+	var api pingdom.CheckAPI = checks
+	check, err := api.Read(12345)
+	assert.NoError(t, err)
+	assert.Equal(t, "down", check.Status)
+
+	update := &pingdom.PingCheck{Name: "A"}
+	_, err = api.Update(12345, update)
+	assert.True(t, errors.Is(err, ErrNotMocked))
+	assert.EqualError(t, err, "pingdommock: CheckAPI.Update: method not mocked")
+
+	assert.Equal(t, []Call{
+		{Method: "Read", Args: []interface{}{12345}},
+		{Method: "Update", Args: []interface{}{12345, update}},
+	}, checks.Calls())
+}
+
+func TestMocksImplementInterfaces(t *testing.T) {
+	client := struct {
+		Checks       pingdom.CheckAPI
+		Contacts     pingdom.ContactServiceAPI
+		Maintenances pingdom.MaintenanceAPI
+		Probes       pingdom.ProbeAPI
+		Teams        pingdom.TeamServiceAPI
+	}{&CheckAPI{}, &ContactServiceAPI{}, &MaintenanceAPI{}, &ProbeAPI{}, &TeamServiceAPI{}}
+
+	_, err := client.Probes.List()
+	assert.True(t, errors.Is(err, ErrNotMocked))
+	_, err = client.Teams.Delete(1)
+	assert.True(t, errors.Is(err, ErrNotMocked))
+	assert.Len(t, client.Probes.(*ProbeAPI).Calls(), 1)
+}
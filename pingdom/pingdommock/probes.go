@@ -0,0 +1,21 @@
+package pingdommock
+
+import "github.com/russellcardullo/go-pingdom/pingdom"
+
+// ProbeAPI is a mock implementation of pingdom.ProbeAPI.
+type ProbeAPI struct {
+	calls
+
+	ListFunc func(params ...map[string]string) ([]pingdom.ProbeResponse, error)
+}
+
+var _ pingdom.ProbeAPI = (*ProbeAPI)(nil)
+
+// List calls ListFunc.
+func (m *ProbeAPI) List(params ...map[string]string) ([]pingdom.ProbeResponse, error) {
+	m.record("List", params)
+	if m.ListFunc == nil {
+		return nil, notMocked("ProbeAPI", "List")
+	}
+	return m.ListFunc(params...)
+}
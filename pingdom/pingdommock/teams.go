@@ -0,0 +1,92 @@
+package pingdommock
+
+import "github.com/russellcardullo/go-pingdom/pingdom"
+
+// TeamServiceAPI is a mock implementation of pingdom.TeamServiceAPI.
+type TeamServiceAPI struct {
+	calls
+
+	ListFunc   func() ([]pingdom.TeamResponse, error)
+	ReadFunc   func(id int) (*pingdom.TeamResponse, error)
+	CreateFunc func(team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+	UpdateFunc func(id int, team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+	DeleteFunc func(id int) (*pingdom.TeamDeleteResponse, error)
+
+	AddMembersFunc    func(id int, contactIDs ...int) (*pingdom.TeamResponse, error)
+	RemoveMembersFunc func(id int, contactIDs ...int) (*pingdom.TeamResponse, error)
+	SetMembersFunc    func(id int, contactIDs ...int) (*pingdom.TeamResponse, error)
+}
+
+var _ pingdom.TeamServiceAPI = (*TeamServiceAPI)(nil)
+
+// List calls ListFunc.
+func (m *TeamServiceAPI) List() ([]pingdom.TeamResponse, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "List")
+	}
+	return m.ListFunc()
+}
+
+// Read calls ReadFunc.
+func (m *TeamServiceAPI) Read(id int) (*pingdom.TeamResponse, error) {
+	m.record("Read", id)
+	if m.ReadFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "Read")
+	}
+	return m.ReadFunc(id)
+}
+
+// Create calls CreateFunc.
+func (m *TeamServiceAPI) Create(team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	m.record("Create", team)
+	if m.CreateFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "Create")
+	}
+	return m.CreateFunc(team)
+}
+
+// Update calls UpdateFunc.
+func (m *TeamServiceAPI) Update(id int, team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	m.record("Update", id, team)
+	if m.UpdateFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "Update")
+	}
+	return m.UpdateFunc(id, team)
+}
+
+// Delete calls DeleteFunc.
+func (m *TeamServiceAPI) Delete(id int) (*pingdom.TeamDeleteResponse, error) {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// AddMembers calls AddMembersFunc.
+func (m *TeamServiceAPI) AddMembers(id int, contactIDs ...int) (*pingdom.TeamResponse, error) {
+	m.record("AddMembers", id, contactIDs)
+	if m.AddMembersFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "AddMembers")
+	}
+	return m.AddMembersFunc(id, contactIDs...)
+}
+
+// RemoveMembers calls RemoveMembersFunc.
+func (m *TeamServiceAPI) RemoveMembers(id int, contactIDs ...int) (*pingdom.TeamResponse, error) {
+	m.record("RemoveMembers", id, contactIDs)
+	if m.RemoveMembersFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "RemoveMembers")
+	}
+	return m.RemoveMembersFunc(id, contactIDs...)
+}
+
+// SetMembers calls SetMembersFunc.
+func (m *TeamServiceAPI) SetMembers(id int, contactIDs ...int) (*pingdom.TeamResponse, error) {
+	m.record("SetMembers", id, contactIDs)
+	if m.SetMembersFunc == nil {
+		return nil, notMocked("TeamServiceAPI", "SetMembers")
+	}
+	return m.SetMembersFunc(id, contactIDs...)
+}
@@ -0,0 +1,499 @@
+package pingdomtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var checkTypes = map[string]bool{
+	"http": true, "httpcustom": true, "tcp": true, "ping": true, "dns": true,
+	"udp": true, "smtp": true, "pop3": true, "imap": true,
+}
+
+// check is the stored state of a check.
+type check struct {
+	pingdom.CheckResponse
+	tags []string
+}
+
+// checkJSON is the wire representation of a check.  The legacy TeamIds
+// field of CheckResponse is not part of the API and is shadowed here so
+// that it is never encoded.
+type checkJSON struct {
+	pingdom.CheckResponse
+	TeamIds *struct{} `json:"TeamIds,omitempty"`
+}
+
+// AddResults seeds raw check results for the check with the given ID, as
+// returned by CheckService.Results.  Results may be added for checks that do
+// not exist yet.
+func (s *Server) AddResults(checkID int, results ...pingdom.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[checkID] = append(s.results[checkID], results...)
+}
+
+// Check returns the current state of a check as it would be returned by
+// CheckService.Read.
+func (s *Server) Check(id int) (pingdom.CheckResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.checks[id]
+	if !ok {
+		return pingdom.CheckResponse{}, false
+	}
+	return s.checkDetails(c, true), true
+}
+
+// UpdateCheck calls fn with the stored state of a check so that tests can
+// simulate changes made by Pingdom itself, such as a check going down.
+// Changes to ID, Tags and Teams are ignored.
+func (s *Server) UpdateCheck(id int, fn func(*pingdom.CheckResponse)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.checks[id]
+	if !ok {
+		return fmt.Errorf("pingdomtest: no check with ID %d", id)
+	}
+	fn(&c.CheckResponse)
+	c.ID = id
+	c.Tags = nil
+	c.Teams = nil
+	return nil
+}
+
+func (s *Server) serveChecks(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case "GET":
+			s.listChecks(w, r)
+		case "POST":
+			s.createCheck(w, r)
+		case "PUT":
+			s.modifyChecks(w, r)
+		case "DELETE":
+			s.deleteChecks(w, r)
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	id, ok := parseID(w, segments[0])
+	if !ok {
+		return
+	}
+	c, ok := s.checks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Check not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]checkJSON{
+			"check": {CheckResponse: s.checkDetails(c, r.Form.Get("include_teams") == "true")},
+		})
+	case "PUT":
+		if err := s.applyCheckParams(c, r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeMessage(w, "Modification of check was successful!")
+	case "DELETE":
+		delete(s.checks, id)
+		writeMessage(w, "Deletion of check was successful!")
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) listChecks(w http.ResponseWriter, r *http.Request) {
+	filter := map[string]bool{}
+	for _, tag := range parseStringList(r.Form.Get("tags")) {
+		filter[tag] = true
+	}
+	includeTags := r.Form.Get("include_tags") == "true"
+
+	checks := []checkJSON{}
+	for _, id := range s.checkIDs() {
+		c := s.checks[id]
+		if len(filter) > 0 && !hasAnyTag(c.tags, filter) {
+			continue
+		}
+
+		summary := pingdom.CheckResponse{
+			ID:               c.ID,
+			Name:             c.Name,
+			Resolution:       c.Resolution,
+			Created:          c.Created,
+			Hostname:         c.Hostname,
+			Status:           c.Status,
+			LastErrorTime:    c.LastErrorTime,
+			LastTestTime:     c.LastTestTime,
+			LastResponseTime: c.LastResponseTime,
+			Paused:           c.Paused,
+			Type:             pingdom.CheckResponseType{Name: c.Type.Name},
+			ProbeFilters:     c.ProbeFilters,
+		}
+		if includeTags {
+			summary.Tags = s.renderTags(c.tags)
+		}
+		checks = append(checks, checkJSON{CheckResponse: summary})
+	}
+
+	offset, limit := paging(r, len(checks), len(checks))
+	writeJSON(w, http.StatusOK, map[string][]checkJSON{"checks": checks[offset:limit]})
+}
+
+func (s *Server) createCheck(w http.ResponseWriter, r *http.Request) {
+	for _, key := range []string{"name", "host", "type"} {
+		if v, _ := formValue(r, key); v == "" {
+			writeError(w, http.StatusBadRequest, "Missing required parameter: "+key)
+			return
+		}
+	}
+	typ := r.Form.Get("type")
+	if !checkTypes[typ] {
+		writeError(w, http.StatusBadRequest, "Invalid check type: "+typ)
+		return
+	}
+
+	c := &check{CheckResponse: pingdom.CheckResponse{
+		Resolution: 5,
+		Created:    now(),
+		Status:     "unknown",
+		Type:       pingdom.CheckResponseType{Name: typ},
+		UserIds:    []int{},
+		TeamIds:    []int{},
+	}}
+	switch typ {
+	case "http":
+		c.Type.HTTP = &pingdom.CheckResponseHTTPDetails{Url: "/", Port: 80, VerifyCertificate: true}
+	case "tcp":
+		c.Type.TCP = &pingdom.CheckResponseTCPDetails{}
+	}
+	if err := s.applyCheckParams(c, r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if typ == "tcp" && c.Type.TCP.Port == 0 {
+		writeError(w, http.StatusBadRequest, "Missing required parameter: port")
+		return
+	}
+
+	if c.Paused {
+		c.Status = "paused"
+	}
+
+	c.ID = s.newID()
+	s.checks[c.ID] = c
+	writeJSON(w, http.StatusOK, map[string]map[string]interface{}{
+		"check": {"id": c.ID, "name": c.Name},
+	})
+}
+
+// modifyChecks implements the bulk modify endpoint, which only supports
+// pausing and changing the resolution of checks.
+func (s *Server) modifyChecks(w http.ResponseWriter, r *http.Request) {
+	ids := s.checkIDs()
+	if v, ok := formValue(r, "checkids"); ok {
+		var err error
+		if ids, err = parseIntList(v); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	for _, id := range ids {
+		if _, ok := s.checks[id]; !ok {
+			writeError(w, http.StatusBadRequest, "Check not found: "+strconv.Itoa(id))
+			return
+		}
+	}
+
+	for key := range r.Form {
+		if key != "checkids" && key != "paused" && key != "resolution" {
+			writeError(w, http.StatusBadRequest, "Parameter not allowed for bulk modification: "+key)
+			return
+		}
+	}
+
+	for _, id := range ids {
+		if err := s.applyCheckParams(s.checks[id], r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	writeMessage(w, fmt.Sprintf("Modification of %d checks was successful!", len(ids)))
+}
+
+func (s *Server) deleteChecks(w http.ResponseWriter, r *http.Request) {
+	ids, err := parseIntList(r.Form.Get("delcheckids"))
+	if err != nil || len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "Missing required parameter: delcheckids")
+		return
+	}
+	for _, id := range ids {
+		if _, ok := s.checks[id]; !ok {
+			writeError(w, http.StatusBadRequest, "Check not found: "+strconv.Itoa(id))
+			return
+		}
+	}
+	for _, id := range ids {
+		delete(s.checks, id)
+	}
+	writeMessage(w, fmt.Sprintf("Deletion of %d checks was successful!", len(ids)))
+}
+
+// applyCheckParams updates a check with the parameters present on the
+// request.  Parameters that are absent leave the check unchanged, which
+// matches the partial update semantics of the API.
+func (s *Server) applyCheckParams(c *check, r *http.Request) error {
+	updated := *c
+	var err error
+
+	str := func(key string, dst *string) {
+		if v, ok := formValue(r, key); ok {
+			*dst = v
+		}
+	}
+	integer := func(key string, dst *int) {
+		if v, ok := formValue(r, key); ok && err == nil {
+			if *dst, err = strconv.Atoi(v); err != nil {
+				err = fmt.Errorf("Invalid value for parameter %s: %s", key, v)
+			}
+		}
+	}
+	boolean := func(key string, dst *bool) {
+		if v, ok := formValue(r, key); ok && err == nil {
+			if *dst, err = strconv.ParseBool(v); err != nil {
+				err = fmt.Errorf("Invalid value for parameter %s: %s", key, v)
+			}
+		}
+	}
+	ints := func(key string, dst *[]int) {
+		if v, ok := formValue(r, key); ok && err == nil {
+			*dst, err = parseIntList(v)
+		}
+	}
+
+	str("name", &updated.Name)
+	str("host", &updated.Hostname)
+	integer("resolution", &updated.Resolution)
+	boolean("paused", &updated.Paused)
+	integer("sendnotificationwhendown", &updated.SendNotificationWhenDown)
+	integer("notifyagainevery", &updated.NotifyAgainEvery)
+	boolean("notifywhenbackup", &updated.NotifyWhenBackup)
+	integer("responsetime_threshold", &updated.ResponseTimeThreshold)
+	ints("integrationids", &updated.IntegrationIds)
+	ints("userids", &updated.UserIds)
+	ints("teamids", &updated.TeamIds)
+	if v, ok := formValue(r, "tags"); ok {
+		updated.tags = parseStringList(v)
+	}
+	if v, ok := formValue(r, "addtags"); ok {
+		updated.tags = pingdom.Tags(updated.tags).Add(parseStringList(v)...)
+	}
+	if v, ok := formValue(r, "probe_filters"); ok && err == nil {
+		filters, perr := pingdom.ParseProbeFilter(v)
+		if perr == nil {
+			perr = filters.Valid()
+		}
+		if perr != nil {
+			err = fmt.Errorf("Invalid value for parameter probe_filters: %s", v)
+		}
+		updated.ProbeFilters = parseStringList(filters.String())
+	}
+
+	if d := c.Type.HTTP; d != nil {
+		details := *d
+		str("url", &details.Url)
+		boolean("encryption", &details.Encryption)
+		integer("port", &details.Port)
+		str("shouldcontain", &details.ShouldContain)
+		str("shouldnotcontain", &details.ShouldNotContain)
+		str("postdata", &details.PostData)
+		boolean("verify_certificate", &details.VerifyCertificate)
+		integer("ssl_down_days_before", &details.SSLDownDaysBefore)
+		if v, ok := formValue(r, "auth"); ok {
+			details.Username, details.Password = v, ""
+			if i := strings.Index(v, ":"); i >= 0 {
+				details.Username, details.Password = v[:i], v[i+1:]
+			}
+		}
+		if headers := requestHeaders(r); headers != nil {
+			details.RequestHeaders = headers
+		}
+		updated.Type.HTTP = &details
+	}
+
+	if d := c.Type.TCP; d != nil {
+		details := *d
+		integer("port", &details.Port)
+		str("stringtosend", &details.StringToSend)
+		str("stringtoexpect", &details.StringToExpect)
+		updated.Type.TCP = &details
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if updated.Name == "" || updated.Hostname == "" {
+		return fmt.Errorf("Parameters name and host must not be empty")
+	}
+	switch updated.Resolution {
+	case 1, 5, 15, 30, 60:
+	default:
+		return fmt.Errorf("Invalid value for parameter resolution: %d", updated.Resolution)
+	}
+	if h := updated.Type.HTTP; h != nil && h.ShouldContain != "" && h.ShouldNotContain != "" {
+		return fmt.Errorf("Parameters shouldcontain and shouldnotcontain are mutually exclusive")
+	}
+	for _, id := range updated.UserIds {
+		if _, ok := s.contacts[id]; !ok {
+			return fmt.Errorf("Invalid user id: %d", id)
+		}
+	}
+	for _, id := range updated.TeamIds {
+		if _, ok := s.teams[id]; !ok {
+			return fmt.Errorf("Invalid team id: %d", id)
+		}
+	}
+
+	if updated.Paused != c.Paused {
+		if updated.Paused {
+			updated.Status = "paused"
+		} else {
+			updated.Status = "unknown"
+		}
+	}
+
+	*c = updated
+	return nil
+}
+
+// requestHeaders collects the numbered requestheaderX parameters.  It
+// returns nil if there are none.
+func requestHeaders(r *http.Request) map[string]string {
+	var headers map[string]string
+	for key, vs := range r.Form {
+		if !strings.HasPrefix(key, "requestheader") || len(vs) == 0 {
+			continue
+		}
+		parts := strings.SplitN(vs[0], ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers[parts[0]] = parts[1]
+	}
+	return headers
+}
+
+// checkDetails renders the detailed form of a check.  Callers must hold s.mu.
+func (s *Server) checkDetails(c *check, includeTeams bool) pingdom.CheckResponse {
+	details := c.CheckResponse
+	details.Tags = s.renderTags(c.tags)
+	details.UserIds = append([]int{}, c.UserIds...)
+	details.TeamIds = nil
+	if includeTeams {
+		details.Teams = []pingdom.CheckTeamResponse{}
+		for _, id := range c.TeamIds {
+			details.Teams = append(details.Teams, pingdom.CheckTeamResponse{ID: id, Name: s.teams[id].Name})
+		}
+	}
+	if c.Type.HTTP != nil {
+		httpDetails := *c.Type.HTTP
+		details.Type.HTTP = &httpDetails
+	}
+	if c.Type.TCP != nil {
+		tcpDetails := *c.Type.TCP
+		details.Type.TCP = &tcpDetails
+	}
+	return details
+}
+
+// renderTags returns the tags of a check with their account wide usage
+// counts.  Callers must hold s.mu.
+func (s *Server) renderTags(tags []string) []pingdom.CheckResponseTag {
+	rendered := []pingdom.CheckResponseTag{}
+	for _, tag := range tags {
+		count := 0
+		for _, c := range s.checks {
+			if hasAnyTag(c.tags, map[string]bool{tag: true}) {
+				count++
+			}
+		}
+		rendered = append(rendered, pingdom.CheckResponseTag{Name: tag, Type: "u", Count: count})
+	}
+	return rendered
+}
+
+func hasAnyTag(tags []string, want map[string]bool) bool {
+	for _, tag := range tags {
+		if want[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIDs returns the IDs of all checks in ascending order.  Callers must
+// hold s.mu.
+func (s *Server) checkIDs() []int {
+	ids := make([]int, 0, len(s.checks))
+	for id := range s.checks {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// removeRecipient removes a contact or team from the alert recipients of
+// all checks.  Callers must hold s.mu.
+func (s *Server) removeRecipient(contactID, teamID int) {
+	for _, c := range s.checks {
+		c.UserIds = removeInt(c.UserIds, contactID)
+		c.TeamIds = removeInt(c.TeamIds, teamID)
+	}
+}
+
+func removeInt(ints []int, v int) []int {
+	out := ints[:0]
+	for _, i := range ints {
+		if i != v {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// paging applies the limit and offset request parameters to a list of n
+// items and returns the bounds of the resulting slice.
+func paging(r *http.Request, n, defaultLimit int) (int, int) {
+	offset, _ := strconv.Atoi(r.Form.Get("offset"))
+	limit, err := strconv.Atoi(r.Form.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
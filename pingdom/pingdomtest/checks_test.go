@@ -0,0 +1,228 @@
+package pingdomtest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLifecycle(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	created, err := client.Checks.Create(&pingdom.HttpCheck{
+		Name:           "Example",
+		Hostname:       "example.com",
+		Resolution:     5,
+		Url:            "/health",
+		Encryption:     true,
+		Port:           443,
+		Tags:           "web,production",
+		RequestHeaders: map[string]string{"X-Test": "1"},
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, "Example", created.Name)
+
+	check, err := client.Checks.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", check.Hostname)
+	assert.Equal(t, "http", check.Type.Name)
+	assert.Equal(t, "/health", check.Type.HTTP.Url)
+	assert.Equal(t, 443, check.Type.HTTP.Port)
+	assert.Equal(t, map[string]string{"X-Test": "1"}, check.Type.HTTP.RequestHeaders)
+	assert.Equal(t, "unknown", check.Status)
+	assert.Len(t, check.Tags, 2)
+
+	_, err = client.Checks.Update(created.ID, &pingdom.HttpCheck{
+		Name:       "Renamed",
+		Hostname:   "example.org",
+		Resolution: 1,
+		Paused:     true,
+	})
+	assert.NoError(t, err)
+
+	check, err = client.Checks.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", check.Name)
+	assert.Equal(t, "example.org", check.Hostname)
+	assert.Equal(t, 1, check.Resolution)
+	assert.Equal(t, "paused", check.Status)
+
+	msg, err := client.Checks.Delete(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Deletion of check was successful!", msg.Message)
+
+	_, err = client.Checks.Read(created.ID)
+	assert.Equal(t, 404, err.(*pingdom.PingdomError).StatusCode)
+}
+
+func TestCheckListFiltersByTag(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	_, err := client.Checks.Create(&pingdom.PingCheck{Name: "A", Hostname: "a.example.com", Resolution: 5, Tags: "web"})
+	assert.NoError(t, err)
+	_, err = client.Checks.Create(&pingdom.TCPCheck{Name: "B", Hostname: "b.example.com", Resolution: 5, Port: 25, Tags: "mail"})
+	assert.NoError(t, err)
+
+	checks, err := client.Checks.List()
+	assert.NoError(t, err)
+	assert.Len(t, checks, 2)
+	assert.Empty(t, checks[0].Tags)
+
+	checks, err = client.Checks.List(map[string]string{"tags": "mail", "include_tags": "true"})
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, "B", checks[0].Name)
+	assert.Equal(t, "tcp", checks[0].Type.Name)
+	assert.Equal(t, "mail", checks[0].Tags[0].Name)
+}
+
+func TestCheckValidationErrors(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	_, err := client.Checks.Create(&pingdom.HttpCheck{Name: "A", Hostname: "example.com", Resolution: 5, UserIds: []int{42}})
+	assert.Equal(t, &pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "Invalid user id: 42"}, err)
+
+	_, err = client.Checks.Update(42, &pingdom.HttpCheck{Name: "A", Hostname: "example.com", Resolution: 5})
+	assert.Equal(t, 404, err.(*pingdom.PingdomError).StatusCode)
+}
+
+func TestCheckProbeFilters(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	created, err := client.Checks.Create(&pingdom.PingCheck{
+		Name:         "A",
+		Hostname:     "example.com",
+		Resolution:   5,
+		ProbeFilters: pingdom.NewProbeFilter(pingdom.RegionEU, pingdom.RegionNA).String(),
+	})
+	assert.NoError(t, err)
+
+	check, err := client.Checks.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"region: EU", "region: NA"}, check.ProbeFilters)
+
+	req, err := client.NewRequest("PUT", "/checks/"+strconv.Itoa(created.ID), map[string]string{"probe_filters": "region: MARS"})
+	assert.NoError(t, err)
+	_, err = client.Do(req, &pingdom.PingdomResponse{})
+	assert.Equal(t, &pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "Invalid value for parameter probe_filters: region: MARS"}, err)
+}
+
+func TestCheckTagOperations(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	var ids []int
+	for _, tags := range []string{"web,old", "old", "db"} {
+		created, err := client.Checks.Create(&pingdom.HttpCheck{Name: "A", Hostname: "example.com", Resolution: 5, Tags: tags})
+		assert.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	assert.NoError(t, client.Checks.AddTag("prod", ids[0], ids[2]))
+	renamed, err := client.Checks.RenameTag("old", "legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, ids[:2], renamed)
+	assert.NoError(t, client.Checks.RemoveTag("web", ids...))
+
+	want := []pingdom.Tags{{"legacy", "prod"}, {"legacy"}, {"db", "prod"}}
+	for i, id := range ids {
+		check, err := client.Checks.Read(id)
+		assert.NoError(t, err)
+		assert.Equal(t, want[i], check.TagSet())
+	}
+
+	checks, err := client.Checks.ListByTag("prod")
+	assert.NoError(t, err)
+	assert.Len(t, checks, 2)
+}
+
+func TestCheckRecipients(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	contact, err := client.Contacts.Create(&pingdom.Contact{Name: "Jane"})
+	assert.NoError(t, err)
+	team, err := client.Teams.Create(&pingdom.Team{Name: "Ops", MemberIDs: []int{contact.ID}})
+	assert.NoError(t, err)
+
+	created, err := client.Checks.Create(&pingdom.PingCheck{
+		Name:       "A",
+		Hostname:   "example.com",
+		Resolution: 5,
+		UserIds:    []int{contact.ID},
+		TeamIds:    []int{team.ID},
+	})
+	assert.NoError(t, err)
+
+	check, err := client.Checks.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{contact.ID}, check.UserIds)
+	assert.Equal(t, []int{team.ID}, check.TeamIds)
+	assert.Equal(t, "Ops", check.Teams[0].Name)
+
+	_, err = client.Teams.Delete(team.ID)
+	assert.NoError(t, err)
+	_, err = client.Contacts.Delete(contact.ID)
+	assert.NoError(t, err)
+
+	check, err = client.Checks.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, check.UserIds)
+	assert.Empty(t, check.TeamIds)
+}
+
+func TestBulkPause(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	a, _ := client.Checks.Create(&pingdom.PingCheck{Name: "A", Hostname: "a.example.com", Resolution: 5})
+	b, _ := client.Checks.Create(&pingdom.PingCheck{Name: "B", Hostname: "b.example.com", Resolution: 5})
+
+	req, err := client.NewRequest("PUT", "/checks", map[string]string{"paused": "true"})
+	assert.NoError(t, err)
+	msg := &pingdom.PingdomResponse{}
+	_, err = client.Do(req, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, "Modification of 2 checks was successful!", msg.Message)
+
+	for _, id := range []int{a.ID, b.ID} {
+		check, ok := server.Check(id)
+		assert.True(t, ok)
+		assert.True(t, check.Paused)
+		assert.Equal(t, "paused", check.Status)
+	}
+}
+
+func TestUpdateCheckSimulatesStatusChange(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	created, _ := client.Checks.Create(&pingdom.PingCheck{Name: "A", Hostname: "example.com", Resolution: 5})
+	err := server.UpdateCheck(created.ID, func(c *pingdom.CheckResponse) {
+		c.Status = "down"
+		c.LastErrorTime = 1600000000
+	})
+	assert.NoError(t, err)
+
+	checks, err := client.Checks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, "down", checks[0].Status)
+	assert.Equal(t, int64(1600000000), checks[0].LastErrorTime)
+
+	assert.Error(t, server.UpdateCheck(1, func(*pingdom.CheckResponse) {}))
+}
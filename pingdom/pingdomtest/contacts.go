@@ -0,0 +1,156 @@
+package pingdomtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// contactRequest is the JSON body accepted when creating or updating a
+// contact.
+type contactRequest struct {
+	Name                *string                      `json:"name"`
+	Paused              *bool                        `json:"paused"`
+	NotificationTargets *pingdom.NotificationTargets `json:"notification_targets"`
+}
+
+// Contact returns the current state of a contact as it would be returned by
+// ContactService.Read.
+func (s *Server) Contact(id int) (pingdom.Contact, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.contacts[id]
+	if !ok {
+		return pingdom.Contact{}, false
+	}
+	return s.contactDetails(c), true
+}
+
+func (s *Server) serveContacts(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case "GET":
+			contacts := []pingdom.Contact{}
+			for _, id := range s.contactIDs() {
+				contacts = append(contacts, s.contactDetails(s.contacts[id]))
+			}
+			writeJSON(w, http.StatusOK, map[string][]pingdom.Contact{"contacts": contacts})
+		case "POST":
+			s.createContact(w, r)
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	id, ok := parseID(w, segments[0])
+	if !ok {
+		return
+	}
+	c, ok := s.contacts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Contact not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]pingdom.Contact{"contact": s.contactDetails(c)})
+	case "PUT":
+		req, ok := decodeContactRequest(w, r)
+		if !ok {
+			return
+		}
+		updated := *c
+		applyContactRequest(&updated, req)
+		if updated.Name == "" {
+			writeError(w, http.StatusBadRequest, "Parameter name must not be empty")
+			return
+		}
+		*c = updated
+		writeMessage(w, "Modification of contact was successful!")
+	case "DELETE":
+		delete(s.contacts, id)
+		for _, t := range s.teams {
+			t.MemberIDs = removeInt(t.MemberIDs, id)
+		}
+		s.removeRecipient(id, 0)
+		writeMessage(w, "Deletion of contact was successful!")
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) createContact(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeContactRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Name == nil || *req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Missing required parameter: name")
+		return
+	}
+
+	c := &pingdom.Contact{Type: "user"}
+	applyContactRequest(c, req)
+	c.ID = s.newID()
+	s.contacts[c.ID] = c
+	writeJSON(w, http.StatusOK, map[string]map[string]int{"contact": {"id": c.ID}})
+}
+
+func decodeContactRequest(w http.ResponseWriter, r *http.Request) (*contactRequest, bool) {
+	req := &contactRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return nil, false
+	}
+	if req.NotificationTargets != nil {
+		if err := req.NotificationTargets.Valid(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return nil, false
+		}
+	}
+	return req, true
+}
+
+func applyContactRequest(c *pingdom.Contact, req *contactRequest) {
+	if req.Name != nil {
+		c.Name = *req.Name
+	}
+	if req.Paused != nil {
+		c.Paused = *req.Paused
+	}
+	if req.NotificationTargets != nil {
+		c.NotificationTargets = *req.NotificationTargets
+	}
+}
+
+// contactDetails renders a contact along with the teams it is a member of.
+// Callers must hold s.mu.
+func (s *Server) contactDetails(c *pingdom.Contact) pingdom.Contact {
+	details := *c
+	details.Teams = []pingdom.ContactTeam{}
+	for _, id := range s.teamIDs() {
+		t := s.teams[id]
+		for _, member := range t.MemberIDs {
+			if member == c.ID {
+				details.Teams = append(details.Teams, pingdom.ContactTeam{ID: t.ID, Name: t.Name})
+				break
+			}
+		}
+	}
+	return details
+}
+
+// contactIDs returns the IDs of all contacts in ascending order.  Callers
+// must hold s.mu.
+func (s *Server) contactIDs() []int {
+	ids := make([]int, 0, len(s.contacts))
+	for id := range s.contacts {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
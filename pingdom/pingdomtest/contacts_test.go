@@ -0,0 +1,61 @@
+package pingdomtest
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactLifecycle(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	contact := pingdom.Contact{
+		Name: "Jane Doe",
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: "jane@example.com", Severity: "HIGH"}},
+		},
+	}
+	created, err := client.Contacts.Create(&contact)
+	assert.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	read, err := client.Contacts.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", read.Name)
+	assert.Equal(t, "user", read.Type)
+	assert.Equal(t, contact.NotificationTargets, read.NotificationTargets)
+
+	contact.Paused = true
+	contact.NotificationTargets.SMS = []pingdom.SMSNotification{{CountryCode: "46", Number: "701234567", Provider: "nexmo", Severity: "LOW"}}
+	_, err = client.Contacts.Update(created.ID, &contact)
+	assert.NoError(t, err)
+
+	contacts, err := client.Contacts.List()
+	assert.NoError(t, err)
+	assert.Len(t, contacts, 1)
+	assert.True(t, contacts[0].Paused)
+	assert.Len(t, contacts[0].NotificationTargets.SMS, 1)
+
+	_, err = client.Contacts.Delete(created.ID)
+	assert.NoError(t, err)
+
+	_, err = client.Contacts.Read(created.ID)
+	assert.Equal(t, 404, err.(*pingdom.PingdomError).StatusCode)
+}
+
+func TestContactListsTeams(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	created, _ := client.Contacts.Create(&pingdom.Contact{Name: "Jane Doe"})
+	team, err := client.Teams.Create(&pingdom.Team{Name: "Ops", MemberIDs: []int{created.ID}})
+	assert.NoError(t, err)
+
+	contact, ok := server.Contact(created.ID)
+	assert.True(t, ok)
+	assert.Equal(t, []pingdom.ContactTeam{{ID: team.ID, Name: "Ops"}}, contact.Teams)
+}
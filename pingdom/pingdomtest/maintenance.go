@@ -0,0 +1,179 @@
+package pingdomtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+var recurrenceTypes = map[string]bool{"none": true, "day": true, "week": true, "month": true}
+
+// Maintenance returns the current state of a maintenance window as it would
+// be returned by MaintenanceService.Read.
+func (s *Server) Maintenance(id int) (pingdom.MaintenanceResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.maintenances[id]
+	if !ok {
+		return pingdom.MaintenanceResponse{}, false
+	}
+	return *m, true
+}
+
+func (s *Server) serveMaintenance(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case "GET":
+			s.listMaintenance(w, r)
+		case "POST":
+			s.createMaintenance(w, r)
+		case "DELETE":
+			s.deleteMaintenances(w, r)
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	id, ok := parseID(w, segments[0])
+	if !ok {
+		return
+	}
+	m, ok := s.maintenances[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Maintenance window not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]*pingdom.MaintenanceResponse{"maintenance": m})
+	case "PUT":
+		updated := *m
+		if err := s.applyMaintenanceParams(&updated, r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		*m = updated
+		writeMessage(w, "Modification of maintenance window was successful!")
+	case "DELETE":
+		delete(s.maintenances, id)
+		writeMessage(w, "Deletion of maintenance window was successful!")
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) listMaintenance(w http.ResponseWriter, r *http.Request) {
+	ids := make([]int, 0, len(s.maintenances))
+	for id := range s.maintenances {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	windows := []pingdom.MaintenanceResponse{}
+	for _, id := range ids {
+		windows = append(windows, *s.maintenances[id])
+	}
+
+	offset, limit := paging(r, len(windows), len(windows))
+	writeJSON(w, http.StatusOK, map[string][]pingdom.MaintenanceResponse{"maintenance": windows[offset:limit]})
+}
+
+func (s *Server) createMaintenance(w http.ResponseWriter, r *http.Request) {
+	for _, key := range []string{"description", "from", "to"} {
+		if v, _ := formValue(r, key); v == "" {
+			writeError(w, http.StatusBadRequest, "Missing required parameter: "+key)
+			return
+		}
+	}
+
+	m := &pingdom.MaintenanceResponse{
+		RecurrenceType: "none",
+		Checks:         pingdom.MaintenanceCheckResponse{Uptime: []int{}, Tms: []int{}},
+	}
+	if err := s.applyMaintenanceParams(m, r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if m.To <= m.From {
+		writeError(w, http.StatusBadRequest, "Parameter to must be later than from")
+		return
+	}
+
+	m.ID = s.newID()
+	s.maintenances[m.ID] = m
+	writeJSON(w, http.StatusOK, map[string]map[string]int{"maintenance": {"id": m.ID}})
+}
+
+func (s *Server) deleteMaintenances(w http.ResponseWriter, r *http.Request) {
+	ids, err := parseIntList(r.Form.Get("maintenanceids"))
+	if err != nil || len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "Missing required parameter: maintenanceids")
+		return
+	}
+	for _, id := range ids {
+		if _, ok := s.maintenances[id]; !ok {
+			writeError(w, http.StatusBadRequest, "Maintenance window not found: "+strconv.Itoa(id))
+			return
+		}
+	}
+	for _, id := range ids {
+		delete(s.maintenances, id)
+	}
+	writeMessage(w, fmt.Sprintf("Deletion of %d maintenance windows was successful!", len(ids)))
+}
+
+// applyMaintenanceParams updates a maintenance window with the parameters
+// present on the request.
+func (s *Server) applyMaintenanceParams(m *pingdom.MaintenanceResponse, r *http.Request) error {
+	if v, ok := formValue(r, "description"); ok {
+		m.Description = v
+	}
+	for key, dst := range map[string]*int64{"from": &m.From, "to": &m.To, "effectiveto": &m.EffectiveTo} {
+		if v, ok := formValue(r, key); ok {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("Invalid value for parameter %s: %s", key, v)
+			}
+			*dst = n
+		}
+	}
+	if v, ok := formValue(r, "recurrencetype"); ok {
+		if !recurrenceTypes[v] {
+			return fmt.Errorf("Invalid value for parameter recurrencetype: %s", v)
+		}
+		m.RecurrenceType = v
+	}
+	if v, ok := formValue(r, "repeatevery"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("Invalid value for parameter repeatevery: %s", v)
+		}
+		m.RepeatEvery = n
+	}
+	for key, dst := range map[string]*[]int{"uptimeids": &m.Checks.Uptime, "tmsids": &m.Checks.Tms} {
+		if v, ok := formValue(r, key); ok {
+			ids, err := parseIntList(v)
+			if err != nil {
+				return fmt.Errorf("Invalid value for parameter %s: %s", key, v)
+			}
+			if key == "uptimeids" {
+				for _, id := range ids {
+					if _, ok := s.checks[id]; !ok {
+						return fmt.Errorf("Invalid check id: %d", id)
+					}
+				}
+			}
+			*dst = ids
+		}
+	}
+
+	if m.Description == "" {
+		return fmt.Errorf("Parameter description must not be empty")
+	}
+	return nil
+}
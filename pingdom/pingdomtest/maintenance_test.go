@@ -0,0 +1,58 @@
+package pingdomtest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceLifecycle(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	check, _ := client.Checks.Create(&pingdom.PingCheck{Name: "A", Hostname: "example.com", Resolution: 5})
+
+	window := pingdom.MaintenanceWindow{
+		Description: "Upgrade",
+		From:        1600000000,
+		To:          1600003600,
+		UptimeIDs:   strconv.Itoa(check.ID),
+	}
+	created, err := client.Maintenances.Create(&window)
+	assert.NoError(t, err)
+
+	read, err := client.Maintenances.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Upgrade", read.Description)
+	assert.Equal(t, "none", read.RecurrenceType)
+	assert.Equal(t, []int{check.ID}, read.Checks.Uptime)
+
+	window.Description = "Longer upgrade"
+	window.To = 1600007200
+	_, err = client.Maintenances.Update(created.ID, &window)
+	assert.NoError(t, err)
+
+	windows, err := client.Maintenances.List()
+	assert.NoError(t, err)
+	assert.Len(t, windows, 1)
+	assert.Equal(t, "Longer upgrade", windows[0].Description)
+	assert.Equal(t, int64(1600007200), windows[0].To)
+
+	_, err = client.Maintenances.MultiDelete(&pingdom.MaintenanceWindowDelete{MaintenanceIDs: strconv.Itoa(created.ID)})
+	assert.NoError(t, err)
+
+	_, ok := server.Maintenance(created.ID)
+	assert.False(t, ok)
+}
+
+func TestMaintenanceRejectsInvalidWindow(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	_, err := client.Maintenances.Create(&pingdom.MaintenanceWindow{Description: "Backwards", From: 1600003600, To: 1600000000})
+	assert.Equal(t, "Parameter to must be later than from", err.(*pingdom.PingdomError).Message)
+}
@@ -0,0 +1,123 @@
+package pingdomtest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// maxResults is the largest page of results the API returns.
+const maxResults = 1000
+
+// AddProbes seeds the probe servers returned by ProbeService.List.
+func (s *Server) AddProbes(probes ...pingdom.ProbeResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probes = append(s.probes, probes...)
+}
+
+func (s *Server) serveProbes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w, r)
+		return
+	}
+
+	onlyActive := r.Form.Get("onlyactive") == "true"
+	probes := []pingdom.ProbeResponse{}
+	for _, p := range s.probes {
+		if onlyActive && !p.Active {
+			continue
+		}
+		probes = append(probes, p)
+	}
+
+	offset, limit := paging(r, len(probes), len(probes))
+	writeJSON(w, http.StatusOK, map[string][]pingdom.ProbeResponse{"probes": probes[offset:limit]})
+}
+
+// serveResults returns the seeded results of a check, newest first, using
+// the same defaults as the API: the last day of results, at most 1000 per
+// request.
+func (s *Server) serveResults(w http.ResponseWriter, r *http.Request, segment string) {
+	if r.Method != "GET" {
+		methodNotAllowed(w, r)
+		return
+	}
+	id, ok := parseID(w, segment)
+	if !ok {
+		return
+	}
+	if _, ok := s.checks[id]; !ok {
+		writeError(w, http.StatusNotFound, "Check not found")
+		return
+	}
+
+	to := time.Now().Unix()
+	if v, ok := formValue(r, "to"); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid value for parameter to: "+v)
+			return
+		}
+		to = n
+	}
+	from := to - 24*60*60
+	if v, ok := formValue(r, "from"); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid value for parameter from: "+v)
+			return
+		}
+		from = n
+	}
+	if limit, err := strconv.Atoi(r.Form.Get("limit")); err == nil && limit > maxResults {
+		writeError(w, http.StatusBadRequest, "Parameter limit must not exceed 1000")
+		return
+	}
+
+	probes := map[int]bool{}
+	probeIDs, err := parseIntList(r.Form.Get("probes"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid value for parameter probes")
+		return
+	}
+	for _, p := range probeIDs {
+		probes[p] = true
+	}
+	statuses := map[string]bool{}
+	for _, status := range parseStringList(r.Form.Get("status")) {
+		statuses[status] = true
+	}
+
+	results := []pingdom.Result{}
+	for _, result := range s.results[id] {
+		t := int64(result.Time)
+		if t < from || t > to {
+			continue
+		}
+		if len(probes) > 0 && !probes[result.ProbeID] {
+			continue
+		}
+		if len(statuses) > 0 && !statuses[result.Status] {
+			continue
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Time > results[j].Time })
+
+	offset, limit := paging(r, len(results), maxResults)
+	page := results[offset:limit]
+
+	active := map[int]bool{}
+	for _, result := range page {
+		active[result.ProbeID] = true
+	}
+
+	writeJSON(w, http.StatusOK, pingdom.ResultsResponse{
+		ActiveProbes: sortedKeys(active),
+		Results:      page,
+	})
+}
@@ -0,0 +1,58 @@
+package pingdomtest
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbesList(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	server.AddProbes(
+		pingdom.ProbeResponse{ID: 1, Name: "Stockholm", Active: true, Region: "EU"},
+		pingdom.ProbeResponse{ID: 2, Name: "Dallas", Active: false, Region: "NA"},
+	)
+
+	probes, err := client.Probes.List()
+	assert.NoError(t, err)
+	assert.Len(t, probes, 2)
+
+	probes, err = client.Probes.List(map[string]string{"onlyactive": "true"})
+	assert.NoError(t, err)
+	assert.Equal(t, []pingdom.ProbeResponse{{ID: 1, Name: "Stockholm", Active: true, Region: "EU"}}, probes)
+}
+
+func TestResultsFiltering(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	check, _ := client.Checks.Create(&pingdom.PingCheck{Name: "A", Hostname: "example.com", Resolution: 1})
+	server.AddResults(check.ID,
+		pingdom.Result{ProbeID: 1, Time: 1600000000, Status: "up", ResponseTime: 100},
+		pingdom.Result{ProbeID: 2, Time: 1600000060, Status: "down", ResponseTime: 0},
+		pingdom.Result{ProbeID: 1, Time: 1600000120, Status: "up", ResponseTime: 120},
+	)
+
+	results, err := client.Checks.Results(check.ID, map[string]string{"from": "1600000000", "to": "1600000120"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, results.ActiveProbes)
+	assert.Len(t, results.Results, 3)
+	assert.Equal(t, 1600000120, results.Results[0].Time, "results are returned newest first")
+
+	results, err = client.Checks.Results(check.ID, map[string]string{"from": "1600000000", "to": "1600000120", "status": "down"})
+	assert.NoError(t, err)
+	assert.Len(t, results.Results, 1)
+	assert.Equal(t, 2, results.Results[0].ProbeID)
+
+	results, err = client.Checks.Results(check.ID, map[string]string{"from": "1600000000", "to": "1600000120", "limit": "1", "offset": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []pingdom.Result{{ProbeID: 2, Time: 1600000060, Status: "down"}}, results.Results)
+
+	_, err = client.Checks.Results(42)
+	assert.Equal(t, 404, err.(*pingdom.PingdomError).StatusCode)
+}
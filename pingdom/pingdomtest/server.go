@@ -0,0 +1,219 @@
+/*
+Package pingdomtest provides an in-memory fake of the Pingdom API for use in
+tests.
+
+The fake keeps checks, contacts, teams, maintenance windows, probes and
+results in memory and implements the create, read, update and delete
+semantics of the real API, including its error responses and token
+authentication.  Probes and results cannot be created through the API, so
+they are seeded with AddProbes and AddResults:
+
+	server := pingdomtest.NewServer("my_api_token")
+	defer server.Close()
+
+	server.AddProbes(pingdom.ProbeResponse{ID: 1, Name: "Stockholm", Active: true})
+	client := server.Client()
+
+	check, err := client.Checks.Create(&pingdom.HttpCheck{Name: "Example", Hostname: "example.com", Resolution: 5})
+*/
+package pingdomtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Server is a fake Pingdom API server.
+type Server struct {
+	*httptest.Server
+
+	token string
+
+	mu           sync.Mutex
+	nextID       int
+	checks       map[int]*check
+	contacts     map[int]*pingdom.Contact
+	teams        map[int]*team
+	maintenances map[int]*pingdom.MaintenanceResponse
+	probes       []pingdom.ProbeResponse
+	results      map[int][]pingdom.Result
+}
+
+// NewServer starts and returns a new fake Pingdom server which accepts
+// requests authenticated with the given API token.  The caller should call
+// Close when finished, to shut it down.
+func NewServer(token string) *Server {
+	s := &Server{
+		token:        token,
+		nextID:       1000,
+		checks:       map[int]*check{},
+		contacts:     map[int]*pingdom.Contact{},
+		teams:        map[int]*team{},
+		maintenances: map[int]*pingdom.MaintenanceResponse{},
+		results:      map[int][]pingdom.Result{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Client returns a pingdom.Client configured to talk to the fake server.
+func (s *Server) Client() *pingdom.Client {
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   s.token,
+		BaseURL:    s.URL,
+		HTTPClient: s.Server.Client(),
+	})
+	if err != nil {
+		panic(fmt.Sprintf("pingdomtest: %v", err))
+	}
+	return client
+}
+
+// ServeHTTP implements the Pingdom API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "Invalid or missing API token")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Accept both the bare server URL and the versioned path used by the
+	// real API as base URL.
+	path := strings.TrimPrefix(r.URL.Path, "/api/3.1")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case segments[0] == "checks" && len(segments) <= 2:
+		s.serveChecks(w, r, segments[1:])
+	case segments[0] == "results" && len(segments) == 2:
+		s.serveResults(w, r, segments[1])
+	case segments[0] == "probes" && len(segments) == 1:
+		s.serveProbes(w, r)
+	case segments[0] == "maintenance" && len(segments) <= 2:
+		s.serveMaintenance(w, r, segments[1:])
+	case segments[0] == "alerting" && len(segments) >= 2 && len(segments) <= 3:
+		switch segments[1] {
+		case "contacts":
+			s.serveContacts(w, r, segments[2:])
+		case "teams":
+			s.serveTeams(w, r, segments[2:])
+		default:
+			writeError(w, http.StatusNotFound, "Unknown resource "+r.URL.Path)
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Unknown resource "+r.URL.Path)
+	}
+}
+
+// newID returns a new unique resource ID.  Callers must hold s.mu.
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// errorJSONResponse mirrors the error envelope returned by Pingdom.
+type errorJSONResponse struct {
+	Error pingdom.PingdomError `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorJSONResponse{
+		Error: pingdom.PingdomError{
+			StatusCode: status,
+			StatusDesc: http.StatusText(status),
+			Message:    message,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeMessage(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusOK, pingdom.PingdomResponse{Message: message})
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "Method "+r.Method+" not allowed for "+r.URL.Path)
+}
+
+// parseID parses a resource ID from a path segment, writing a 404 error
+// response if it is malformed.
+func parseID(w http.ResponseWriter, segment string) (int, bool) {
+	id, err := strconv.Atoi(segment)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Invalid resource ID "+segment)
+		return 0, false
+	}
+	return id, true
+}
+
+// parseIntList parses a comma separated list of integers.
+func parseIntList(s string) ([]int, error) {
+	ids := []int{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseStringList parses a comma separated list of strings.
+func parseStringList(s string) []string {
+	var l []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			l = append(l, part)
+		}
+	}
+	return l
+}
+
+// formValue returns the value of a request parameter and whether it was
+// present at all, so that empty values can be used to clear fields.
+func formValue(r *http.Request, key string) (string, bool) {
+	vs, ok := r.Form[key]
+	if !ok || len(vs) == 0 {
+		return "", false
+	}
+	return vs[0], true
+}
+
+func now() int64 {
+	return time.Now().Unix()
+}
+
+func sortedKeys(m map[int]bool) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,57 @@
+package pingdomtest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+const testToken = "my_api_key"
+
+func TestServerRejectsInvalidToken(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken: "wrong",
+		BaseURL:  server.URL,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Checks.List()
+	assert.Equal(t, &pingdom.PingdomError{
+		StatusCode: http.StatusUnauthorized,
+		StatusDesc: "Unauthorized",
+		Message:    "Invalid or missing API token",
+	}, err)
+}
+
+func TestServerUnknownResource(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	req, err := client.NewRequest("GET", "/widgets", nil)
+	assert.NoError(t, err)
+
+	_, err = client.Do(req, &pingdom.PingdomResponse{})
+	assert.IsType(t, &pingdom.PingdomError{}, err)
+	assert.Equal(t, http.StatusNotFound, err.(*pingdom.PingdomError).StatusCode)
+}
+
+func TestServerAcceptsVersionedBaseURL(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken: testToken,
+		BaseURL:  server.URL + "/api/3.1",
+	})
+	assert.NoError(t, err)
+
+	checks, err := client.Checks.List()
+	assert.NoError(t, err)
+	assert.Empty(t, checks)
+}
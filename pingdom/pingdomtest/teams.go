@@ -0,0 +1,137 @@
+package pingdomtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// team is the stored state of a team.
+type team struct {
+	ID        int
+	Name      string
+	MemberIDs []int
+}
+
+// teamRequest is the JSON body accepted when creating or updating a team.
+type teamRequest struct {
+	Name      string `json:"name"`
+	MemberIDs []int  `json:"member_ids"`
+}
+
+// Team returns the current state of a team as it would be returned by
+// TeamService.Read.
+func (s *Server) Team(id int) (pingdom.TeamResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.teams[id]
+	if !ok {
+		return pingdom.TeamResponse{}, false
+	}
+	return s.teamDetails(t), true
+}
+
+func (s *Server) serveTeams(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case "GET":
+			teams := []pingdom.TeamResponse{}
+			for _, id := range s.teamIDs() {
+				teams = append(teams, s.teamDetails(s.teams[id]))
+			}
+			writeJSON(w, http.StatusOK, map[string][]pingdom.TeamResponse{"teams": teams})
+		case "POST":
+			t := &team{}
+			if !s.applyTeamRequest(w, r, t) {
+				return
+			}
+			t.ID = s.newID()
+			s.teams[t.ID] = t
+			writeJSON(w, http.StatusOK, map[string]pingdom.TeamResponse{"team": s.teamDetails(t)})
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	id, ok := parseID(w, segments[0])
+	if !ok {
+		return
+	}
+	t, ok := s.teams[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]pingdom.TeamResponse{"team": s.teamDetails(t)})
+	case "PUT":
+		if !s.applyTeamRequest(w, r, t) {
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]pingdom.TeamResponse{"team": s.teamDetails(t)})
+	case "DELETE":
+		delete(s.teams, id)
+		s.removeRecipient(0, id)
+		writeMessage(w, "Deletion of team was successful!")
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// applyTeamRequest decodes and validates a team request and applies it to
+// t.  It writes an error response and returns false if the request is
+// invalid.
+func (s *Server) applyTeamRequest(w http.ResponseWriter, r *http.Request, t *team) bool {
+	req := &teamRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Missing required parameter: name")
+		return false
+	}
+
+	members := map[int]bool{}
+	for _, id := range req.MemberIDs {
+		if _, ok := s.contacts[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid member id: %d", id))
+			return false
+		}
+		members[id] = true
+	}
+
+	t.Name = req.Name
+	t.MemberIDs = sortedKeys(members)
+	return true
+}
+
+// teamDetails renders a team with its members.  Callers must hold s.mu.
+func (s *Server) teamDetails(t *team) pingdom.TeamResponse {
+	details := pingdom.TeamResponse{ID: t.ID, Name: t.Name, Members: []pingdom.TeamMemberResponse{}}
+	for _, id := range t.MemberIDs {
+		details.Members = append(details.Members, pingdom.TeamMemberResponse{
+			ID:   id,
+			Name: s.contacts[id].Name,
+			Type: s.contacts[id].Type,
+		})
+	}
+	return details
+}
+
+// teamIDs returns the IDs of all teams in ascending order.  Callers must
+// hold s.mu.
+func (s *Server) teamIDs() []int {
+	ids := make([]int, 0, len(s.teams))
+	for id := range s.teams {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
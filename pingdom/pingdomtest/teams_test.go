@@ -0,0 +1,50 @@
+package pingdomtest
+
+import (
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamLifecycle(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	jane, _ := client.Contacts.Create(&pingdom.Contact{Name: "Jane"})
+	john, _ := client.Contacts.Create(&pingdom.Contact{Name: "John"})
+
+	team, err := client.Teams.Create(&pingdom.Team{Name: "Ops", MemberIDs: []int{jane.ID}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ops", team.Name)
+	assert.Equal(t, []pingdom.TeamMemberResponse{{ID: jane.ID, Name: "Jane", Type: "user"}}, team.Members)
+
+	updated, err := client.Teams.Update(team.ID, &pingdom.Team{Name: "Ops", MemberIDs: []int{jane.ID, john.ID}})
+	assert.NoError(t, err)
+	assert.Len(t, updated.Members, 2)
+
+	_, err = client.Contacts.Delete(john.ID)
+	assert.NoError(t, err)
+
+	read, err := client.Teams.Read(team.ID)
+	assert.NoError(t, err)
+	assert.Len(t, read.Members, 1)
+
+	teams, err := client.Teams.List()
+	assert.NoError(t, err)
+	assert.Len(t, teams, 1)
+
+	msg, err := client.Teams.Delete(team.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Deletion of team was successful!", msg.Message)
+}
+
+func TestTeamRejectsUnknownMembers(t *testing.T) {
+	server := NewServer(testToken)
+	defer server.Close()
+	client := server.Client()
+
+	_, err := client.Teams.Create(&pingdom.Team{Name: "Ops", MemberIDs: []int{42}})
+	assert.Equal(t, &pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "Invalid member id: 42"}, err)
+}
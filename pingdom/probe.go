@@ -10,6 +10,14 @@ type ProbeService struct {
 	client *Client
 }
 
+// ProbeAPI is the set of operations provided by ProbeService.  Code that
+// accepts a ProbeAPI rather than a *ProbeService can be tested with a mock.
+type ProbeAPI interface {
+	List(params ...map[string]string) ([]ProbeResponse, error)
+}
+
+var _ ProbeAPI = (*ProbeService)(nil)
+
 // List return a list of probes from Pingdom.
 func (cs *ProbeService) List(params ...map[string]string) ([]ProbeResponse, error) {
 	param := map[string]string{}
@@ -21,7 +29,7 @@ func (cs *ProbeService) List(params ...map[string]string) ([]ProbeResponse, erro
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,144 @@
+package pingdom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Region is a region of probe servers, as reported by ProbeService.List and
+// used in probe filters.
+type Region string
+
+// Regions of probe servers.
+const (
+	RegionNA    Region = "NA"
+	RegionEU    Region = "EU"
+	RegionAPAC  Region = "APAC"
+	RegionLATAM Region = "LATAM"
+)
+
+// Regions is the list of regions supported by the API.
+var Regions = []Region{RegionNA, RegionEU, RegionAPAC, RegionLATAM}
+
+// ProbeFilter selects the probe servers used by a check.  The API currently
+// only supports filtering by region.  The zero value selects all probes.
+//
+// Use String to set the ProbeFilters field of HttpCheck, PingCheck or
+// TCPCheck:
+//
+//	check := pingdom.HttpCheck{
+//		Name:         "Example",
+//		Hostname:     "example.com",
+//		ProbeFilters: pingdom.NewProbeFilter(pingdom.RegionEU).String(),
+//	}
+type ProbeFilter struct {
+	Regions []Region
+}
+
+// NewProbeFilter returns a ProbeFilter selecting probes in the given
+// regions.
+func NewProbeFilter(regions ...Region) ProbeFilter {
+	return ProbeFilter{Regions: regions}
+}
+
+// ParseProbeFilter parses probe filters in the format sent to the API, e.g.
+// "region: NA,region: EU", or as returned in CheckResponse.ProbeFilters.
+func ParseProbeFilter(values ...string) (ProbeFilter, error) {
+	f := ProbeFilter{}
+	for _, value := range values {
+		for _, filter := range strings.Split(value, ",") {
+			filter = strings.TrimSpace(filter)
+			if filter == "" {
+				continue
+			}
+			i := strings.Index(filter, ":")
+			if i < 0 {
+				return ProbeFilter{}, fmt.Errorf("Invalid value %q for `ProbeFilters`.  Must be of the form \"region: <region>\"", filter)
+			}
+			key, v := strings.TrimSpace(filter[:i]), strings.TrimSpace(filter[i+1:])
+			if key != "region" {
+				return ProbeFilter{}, fmt.Errorf("Invalid value %q for `ProbeFilters`.  Only region filters are supported", filter)
+			}
+			f.Regions = append(f.Regions, Region(strings.ToUpper(v)))
+		}
+	}
+	return f, nil
+}
+
+// String returns the probe filter in the format sent to the API.
+func (f ProbeFilter) String() string {
+	filters := make([]string, len(f.Regions))
+	for i, region := range f.Regions {
+		filters[i] = "region: " + string(region)
+	}
+	return strings.Join(filters, ",")
+}
+
+// Valid determines whether the filter only uses regions supported by the API.
+func (f ProbeFilter) Valid() error {
+	return f.validRegions(Regions)
+}
+
+// ValidFor determines whether every region of the filter has an active probe
+// in probes, as returned by ProbeService.List.
+func (f ProbeFilter) ValidFor(probes []ProbeResponse) error {
+	return f.validRegions(ProbeRegions(probes))
+}
+
+func (f ProbeFilter) validRegions(known []Region) error {
+	for _, region := range f.Regions {
+		found := false
+		for _, k := range known {
+			if region == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Invalid region %q for `ProbeFilters`.  Must be one of %v", region, known)
+		}
+	}
+	return nil
+}
+
+// ProbeRegions returns the regions of the active probes, in the order they
+// first appear.
+func ProbeRegions(probes []ProbeResponse) []Region {
+	seen := map[Region]bool{}
+	var regions []Region
+	for _, p := range probes {
+		region := Region(p.Region)
+		if !p.Active || region == "" || seen[region] {
+			continue
+		}
+		seen[region] = true
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// ParsedProbeFilters parses the probe filters of the check.
+func (cr *CheckResponse) ParsedProbeFilters() (ProbeFilter, error) {
+	return ParseProbeFilter(cr.ProbeFilters...)
+}
+
+// validProbeFilters validates the ProbeFilters field of a check.
+func validProbeFilters(filters string) error {
+	f, err := ParseProbeFilter(filters)
+	if err != nil {
+		return err
+	}
+	return f.Valid()
+}
+
+// normalizeProbeFilters returns the ProbeFilters field of a check in the
+// form it was validated in, e.g. "region: EU" for "region:eu", so that the
+// API receives what Valid accepted.  Filters that cannot be parsed are
+// returned unchanged for Valid to report.
+func normalizeProbeFilters(filters string) string {
+	f, err := ParseProbeFilter(filters)
+	if err != nil {
+		return filters
+	}
+	return f.String()
+}
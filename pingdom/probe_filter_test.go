@@ -0,0 +1,92 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeFilterString(t *testing.T) {
+	assert.Equal(t, "", ProbeFilter{}.String())
+	assert.Equal(t, "region: NA", NewProbeFilter(RegionNA).String())
+	assert.Equal(t, "region: EU,region: APAC", NewProbeFilter(RegionEU, RegionAPAC).String())
+}
+
+func TestParseProbeFilter(t *testing.T) {
+	f, err := ParseProbeFilter("region: NA,region:eu")
+	assert.NoError(t, err)
+	assert.Equal(t, NewProbeFilter(RegionNA, RegionEU), f)
+
+	f, err = ParseProbeFilter("region: APAC", "region: LATAM")
+	assert.NoError(t, err)
+	assert.Equal(t, NewProbeFilter(RegionAPAC, RegionLATAM), f)
+
+	f, err = ParseProbeFilter("")
+	assert.NoError(t, err)
+	assert.Empty(t, f.Regions)
+
+	_, err = ParseProbeFilter("NA")
+	assert.EqualError(t, err, "Invalid value \"NA\" for `ProbeFilters`.  Must be of the form \"region: <region>\"")
+
+	_, err = ParseProbeFilter("country: SE")
+	assert.EqualError(t, err, "Invalid value \"country: SE\" for `ProbeFilters`.  Only region filters are supported")
+}
+
+func TestProbeFilterValid(t *testing.T) {
+	assert.NoError(t, ProbeFilter{}.Valid())
+	assert.NoError(t, NewProbeFilter(Regions...).Valid())
+	assert.EqualError(t, NewProbeFilter(RegionNA, "MARS").Valid(),
+		"Invalid region \"MARS\" for `ProbeFilters`.  Must be one of [NA EU APAC LATAM]")
+}
+
+func TestProbeFilterValidFor(t *testing.T) {
+	probes := []ProbeResponse{
+		{ID: 1, Active: true, Region: "NA"},
+		{ID: 2, Active: true, Region: "EU"},
+		{ID: 3, Active: true, Region: "NA"},
+		{ID: 4, Active: false, Region: "LATAM"},
+	}
+	assert.Equal(t, []Region{RegionNA, RegionEU}, ProbeRegions(probes))
+
+	assert.NoError(t, NewProbeFilter(RegionEU).ValidFor(probes))
+	assert.EqualError(t, NewProbeFilter(RegionLATAM).ValidFor(probes),
+		"Invalid region \"LATAM\" for `ProbeFilters`.  Must be one of [NA EU]")
+}
+
+func TestCheckResponseParsedProbeFilters(t *testing.T) {
+	cr := &CheckResponse{ProbeFilters: []string{"region: NA", "region: EU"}}
+	f, err := cr.ParsedProbeFilters()
+	assert.NoError(t, err)
+	assert.Equal(t, NewProbeFilter(RegionNA, RegionEU), f)
+}
+
+func TestCheckValidProbeFilters(t *testing.T) {
+	checks := []Check{
+		&HttpCheck{Name: "fake check", Hostname: "example.com", Resolution: 15},
+		&PingCheck{Name: "fake check", Hostname: "example.com", Resolution: 15},
+		&TCPCheck{Name: "fake check", Hostname: "example.com", Resolution: 15, Port: 22},
+	}
+	set := func(c Check, filters string) {
+		switch c := c.(type) {
+		case *HttpCheck:
+			c.ProbeFilters = filters
+		case *PingCheck:
+			c.ProbeFilters = filters
+		case *TCPCheck:
+			c.ProbeFilters = filters
+		}
+	}
+
+	for _, c := range checks {
+		set(c, NewProbeFilter(RegionAPAC).String())
+		assert.NoError(t, c.Valid())
+
+		set(c, "region: MARS")
+		assert.Error(t, c.Valid())
+
+		set(c, " region:eu, region: na ")
+		assert.NoError(t, c.Valid())
+		assert.Equal(t, "region: EU,region: NA", c.PutParams()["probe_filters"])
+		assert.Equal(t, "region: EU,region: NA", c.PostParams()["probe_filters"])
+	}
+}
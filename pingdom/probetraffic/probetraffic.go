@@ -0,0 +1,224 @@
+/*
+Package probetraffic identifies requests sent by Pingdom probes, so that
+access logs and metrics can tell synthetic traffic from real users.
+
+	d := probetraffic.New(probetraffic.Config{Probes: client.Probes})
+	if err := d.Refresh(); err != nil {
+		log.Println(err)
+	}
+	http.Handle("/", d.Middleware(handler))
+
+Handlers read the probe that sent a request from its context:
+
+	if probe, ok := probetraffic.FromContext(r.Context()); ok {
+		log.Printf("request from Pingdom probe %s (%s)", probe.Name, probe.Country)
+	}
+
+The addresses of the probes are listed with ProbeService.List and refreshed
+in the background once they are older than Config.Interval.
+*/
+package probetraffic
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const defaultInterval = time.Hour
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the probe that sent a request.
+func NewContext(ctx context.Context, probe pingdom.ProbeResponse) context.Context {
+	return context.WithValue(ctx, contextKey{}, probe)
+}
+
+// FromContext returns the probe set by the middleware of a Detector, if the
+// request was sent by a probe.
+func FromContext(ctx context.Context) (pingdom.ProbeResponse, bool) {
+	probe, ok := ctx.Value(contextKey{}).(pingdom.ProbeResponse)
+	return probe, ok
+}
+
+// Config configures a Detector.
+type Config struct {
+	// Probes is used to list probes.  It is usually the Probes service of
+	// a pingdom.Client.
+	Probes pingdom.ProbeAPI
+
+	// Interval is the time after which the probes are listed again.  It
+	// defaults to one hour.
+	Interval time.Duration
+
+	// TrustedProxies are the proxies whose X-Forwarded-For header is
+	// used to find the address of the client.  If empty, only the remote
+	// address of the connection is used.
+	TrustedProxies []netip.Prefix
+
+	// OnError, if set, is called with errors returned by Probes.List
+	// during background refreshes.  The previous probes are kept.
+	OnError func(error)
+}
+
+// Detector matches the addresses of requests with those of the probes.
+type Detector struct {
+	config Config
+	now    func() time.Time
+
+	mu         sync.RWMutex
+	probes     map[netip.Addr]pingdom.ProbeResponse
+	refreshed  time.Time
+	refreshing bool
+}
+
+// New returns a Detector with the given configuration.  It does not know
+// any probe until Refresh is called or the middleware refreshes it.
+func New(config Config) *Detector {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	return &Detector{config: config, now: time.Now}
+}
+
+// Refresh lists the probes now.
+func (d *Detector) Refresh() error {
+	list, err := d.config.Probes.List()
+	if err != nil {
+		return err
+	}
+
+	probes := map[netip.Addr]pingdom.ProbeResponse{}
+	for _, p := range list {
+		for _, ip := range []string{p.IP, p.IPv6} {
+			if addr, err := netip.ParseAddr(ip); err == nil {
+				probes[addr.Unmap()] = p
+			}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.probes = probes
+	d.refreshed = d.now()
+	return nil
+}
+
+// Lookup returns the probe with the given address.
+func (d *Detector) Lookup(addr netip.Addr) (pingdom.ProbeResponse, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	probe, ok := d.probes[addr.Unmap()]
+	return probe, ok
+}
+
+// Probe returns the probe that sent r, if any.
+func (d *Detector) Probe(r *http.Request) (pingdom.ProbeResponse, bool) {
+	addr, ok := d.clientAddr(r)
+	if !ok {
+		return pingdom.ProbeResponse{}, false
+	}
+	return d.Lookup(addr)
+}
+
+// Middleware returns a handler that adds the probe that sent each request
+// to its context, see FromContext, before calling next.  It starts a
+// background refresh when the probes are older than Config.Interval.
+func (d *Detector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.refreshIfStale()
+		if probe, ok := d.Probe(r); ok {
+			r = r.WithContext(NewContext(r.Context(), probe))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// refreshIfStale starts a background refresh unless the probes are recent
+// or a refresh is running.  Staleness is checked under the read lock first so
+// that concurrent requests do not wait on each other while the probes are
+// recent.
+func (d *Detector) refreshIfStale() {
+	d.mu.RLock()
+	due := d.refreshDue()
+	d.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.refreshDue() {
+		return
+	}
+	d.refreshing = true
+
+	go func() {
+		err := d.Refresh()
+		d.mu.Lock()
+		d.refreshing = false
+		if err != nil {
+			// Retry after another interval rather than on every request.
+			d.refreshed = d.now()
+		}
+		d.mu.Unlock()
+		if err != nil && d.config.OnError != nil {
+			d.config.OnError(err)
+		}
+	}()
+}
+
+// refreshDue reports whether a refresh should be started.  d.mu must be
+// held.
+func (d *Detector) refreshDue() bool {
+	return !d.refreshing && (d.refreshed.IsZero() || d.now().Sub(d.refreshed) >= d.config.Interval)
+}
+
+// clientAddr returns the address of the client that sent r.  The
+// X-Forwarded-For header is read from right to left, skipping trusted
+// proxies, as long as the request came through a trusted proxy.
+func (d *Detector) clientAddr(r *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr = addr.Unmap()
+	if !d.trusted(addr) {
+		return addr, true
+	}
+
+	var forwarded []string
+	for _, h := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(h, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		a, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		addr = a.Unmap()
+		if !d.trusted(addr) {
+			break
+		}
+	}
+	return addr, true
+}
+
+func (d *Detector) trusted(addr netip.Addr) bool {
+	for _, p := range d.config.TrustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package probetraffic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/stretchr/testify/assert"
+)
+
+var probes = []pingdom.ProbeResponse{
+	{ID: 1, Name: "Stockholm", Country: "Sweden", IP: "10.0.0.1", IPv6: "2001:db8::1"},
+	{ID: 2, Name: "Dallas", Country: "United States", IP: "10.0.0.2"},
+}
+
+func mockProbes(list []pingdom.ProbeResponse, err error) *pingdommock.ProbeAPI {
+	return &pingdommock.ProbeAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.ProbeResponse, error) {
+			return list, err
+		},
+	}
+}
+
+// serve sends a request through the middleware and returns the probe the
+// handler saw.
+func serve(d *Detector, remoteAddr string, forwardedFor ...string) (pingdom.ProbeResponse, bool) {
+	var probe pingdom.ProbeResponse
+	var ok bool
+	handler := d.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probe, ok = FromContext(r.Context())
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = remoteAddr
+	for _, f := range forwardedFor {
+		req.Header.Add("X-Forwarded-For", f)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	return probe, ok
+}
+
+func TestLookup(t *testing.T) {
+	d := New(Config{Probes: mockProbes(probes, nil)})
+	_, ok := d.Lookup(netip.MustParseAddr("10.0.0.1"))
+	assert.False(t, ok, "no probes are known before a refresh")
+
+	assert.NoError(t, d.Refresh())
+
+	probe, ok := d.Lookup(netip.MustParseAddr("10.0.0.1"))
+	assert.True(t, ok)
+	assert.Equal(t, "Stockholm", probe.Name)
+	probe, ok = d.Lookup(netip.MustParseAddr("2001:db8::1"))
+	assert.True(t, ok)
+	assert.Equal(t, 1, probe.ID)
+	probe, ok = d.Lookup(netip.MustParseAddr("::ffff:10.0.0.2"))
+	assert.True(t, ok)
+	assert.Equal(t, "Dallas", probe.Name)
+	_, ok = d.Lookup(netip.MustParseAddr("10.0.0.3"))
+	assert.False(t, ok)
+}
+
+func TestMiddleware(t *testing.T) {
+	d := New(Config{Probes: mockProbes(probes, nil)})
+	assert.NoError(t, d.Refresh())
+
+	probe, ok := serve(d, "10.0.0.2:51000")
+	assert.True(t, ok)
+	assert.Equal(t, "United States", probe.Country)
+
+	probe, ok = serve(d, "[2001:db8::1]:443")
+	assert.True(t, ok)
+	assert.Equal(t, "Stockholm", probe.Name)
+
+	_, ok = serve(d, "192.0.2.1:51000")
+	assert.False(t, ok)
+
+	_, ok = serve(d, "192.0.2.1:51000", "10.0.0.1")
+	assert.False(t, ok, "X-Forwarded-For must be ignored unless the proxy is trusted")
+}
+
+func TestMiddleware_TrustedProxies(t *testing.T) {
+	d := New(Config{
+		Probes:         mockProbes(probes, nil),
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("192.168.0.0/16")},
+	})
+	assert.NoError(t, d.Refresh())
+
+	probe, ok := serve(d, "192.168.1.1:51000", "10.0.0.1, 192.168.1.2")
+	assert.True(t, ok)
+	assert.Equal(t, 1, probe.ID)
+
+	probe, ok = serve(d, "192.168.1.1:51000", "10.0.0.2", "192.168.5.5")
+	assert.True(t, ok)
+	assert.Equal(t, 2, probe.ID)
+
+	_, ok = serve(d, "192.168.1.1:51000", "10.0.0.1, 203.0.113.7")
+	assert.False(t, ok, "a spoofed address left of an untrusted client must be ignored")
+
+	_, ok = serve(d, "192.168.1.1:51000", "garbage")
+	assert.False(t, ok)
+}
+
+func TestMiddleware_Refresh(t *testing.T) {
+	var mu sync.Mutex
+	list := probes[:1]
+	calls := make(chan struct{}, 10)
+	mock := &pingdommock.ProbeAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.ProbeResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls <- struct{}{}
+			return list, nil
+		},
+	}
+	d := New(Config{Probes: mock, Interval: time.Minute})
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+
+	serve(d, "10.0.0.1:1")
+	<-calls
+	waitFor(t, func() bool { _, ok := d.Lookup(netip.MustParseAddr("10.0.0.1")); return ok })
+
+	mu.Lock()
+	list = probes
+	mu.Unlock()
+	serve(d, "10.0.0.1:1")
+	select {
+	case <-calls:
+		t.Fatal("probes should not be refreshed before the interval")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	now = now.Add(time.Minute)
+	serve(d, "10.0.0.1:1")
+	<-calls
+	waitFor(t, func() bool { _, ok := d.Lookup(netip.MustParseAddr("10.0.0.2")); return ok })
+}
+
+func TestMiddleware_ConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	release := make(chan struct{})
+	mock := &pingdommock.ProbeAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.ProbeResponse, error) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			<-release
+			return probes, nil
+		},
+	}
+	d := New(Config{Probes: mock, Interval: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serve(d, "10.0.0.1:1")
+		}()
+	}
+	wg.Wait()
+	close(release)
+	waitFor(t, func() bool { _, ok := d.Lookup(netip.MustParseAddr("10.0.0.1")); return ok })
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok := serve(d, "10.0.0.2:1")
+			assert.True(t, ok)
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, calls, "Only one refresh should be started")
+}
+
+func TestMiddleware_RefreshError(t *testing.T) {
+	failure := errors.New("failure")
+	errs := make(chan error, 1)
+	d := New(Config{Probes: mockProbes(nil, failure), OnError: func(err error) { errs <- err }})
+
+	_, ok := serve(d, "10.0.0.1:1")
+	assert.False(t, ok)
+	assert.Equal(t, failure, <-errs)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
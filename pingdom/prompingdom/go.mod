@@ -0,0 +1,27 @@
+module github.com/russellcardullo/go-pingdom/pingdom/prompingdom
+
+go 1.20
+
+require (
+	github.com/prometheus/client_golang v1.19.1
+	github.com/russellcardullo/go-pingdom v1.4.0
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Build against the library in this repository.  Modules requiring this one
+// ignore the replace directive and use the version required above.
+replace github.com/russellcardullo/go-pingdom => ../..
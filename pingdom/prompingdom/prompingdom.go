@@ -0,0 +1,192 @@
+/*
+Package prompingdom collects Prometheus metrics about a client's use of the
+Pingdom API: request counts, latencies and errors per endpoint and status, and
+the rate limits last reported by the API.
+
+Register the collector and add its middleware to the client configuration:
+
+	collector := prompingdom.NewCollector()
+	prometheus.MustRegister(collector)
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "pingdom_api_token",
+		Middleware: []pingdom.Middleware{collector.Middleware()},
+	})
+*/
+package prompingdom
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Rate limit windows reported by the API in the Req-Limit-Short and
+// Req-Limit-Long response headers.
+var rateLimitHeaders = map[string]string{
+	"short": "Req-Limit-Short",
+	"long":  "Req-Limit-Long",
+}
+
+// statusError is the status label of requests that failed without a
+// response.
+const statusError = "error"
+
+// Option configures a Collector.
+type Option func(*options)
+
+type options struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// WithNamespace sets the namespace of the metric names, "pingdom" by
+// default.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithConstLabels adds labels to every metric, e.g. to distinguish several
+// clients registered with the same registry.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+// WithBuckets sets the buckets of the request duration histogram.
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// Collector is a prometheus.Collector recording API usage of the clients
+// using its middleware.
+type Collector struct {
+	requests           *prometheus.CounterVec
+	errors             *prometheus.CounterVec
+	duration           *prometheus.HistogramVec
+	rateLimitRemaining *prometheus.GaugeVec
+	rateLimitReset     *prometheus.GaugeVec
+}
+
+// NewCollector returns a new Collector.
+func NewCollector(opts ...Option) *Collector {
+	o := &options{namespace: "pingdom", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "requests_total",
+			Help:        "Number of requests sent to the Pingdom API.",
+			ConstLabels: o.constLabels,
+		}, []string{"endpoint", "method", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "errors_total",
+			Help:        "Number of requests to the Pingdom API that failed or returned an error status.",
+			ConstLabels: o.constLabels,
+		}, []string{"endpoint", "method", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "request_duration_seconds",
+			Help:        "Duration of requests to the Pingdom API.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}, []string{"endpoint", "method"}),
+		rateLimitRemaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "rate_limit_remaining",
+			Help:        "Requests remaining in the rate limit window, as last reported by the Pingdom API.",
+			ConstLabels: o.constLabels,
+		}, []string{"window"}),
+		rateLimitReset: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "rate_limit_reset_seconds",
+			Help:        "Seconds until the rate limit window resets, as last reported by the Pingdom API.",
+			ConstLabels: o.constLabels,
+		}, []string{"window"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.rateLimitRemaining.Describe(ch)
+	c.rateLimitReset.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.rateLimitRemaining.Collect(ch)
+	c.rateLimitReset.Collect(ch)
+}
+
+// Middleware returns a pingdom.Middleware recording the requests of a
+// client.  The same collector can be used by several clients.
+func (c *Collector) Middleware() pingdom.Middleware {
+	return func(next pingdom.RequestHandler) pingdom.RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			endpoint := pingdom.Endpoint(req)
+			start := time.Now()
+			resp, err := next(req)
+			c.duration.WithLabelValues(endpoint, req.Method).Observe(time.Since(start).Seconds())
+
+			status := statusError
+			if err == nil {
+				status = strconv.Itoa(resp.StatusCode)
+				c.observeRateLimits(resp.Header)
+			}
+			c.requests.WithLabelValues(endpoint, req.Method, status).Inc()
+			if err != nil || resp.StatusCode >= 400 {
+				c.errors.WithLabelValues(endpoint, req.Method, status).Inc()
+			}
+			return resp, err
+		}
+	}
+}
+
+func (c *Collector) observeRateLimits(h http.Header) {
+	for window, header := range rateLimitHeaders {
+		remaining, reset, ok := parseRateLimit(h.Get(header))
+		if !ok {
+			continue
+		}
+		c.rateLimitRemaining.WithLabelValues(window).Set(float64(remaining))
+		c.rateLimitReset.WithLabelValues(window).Set(reset.Seconds())
+	}
+}
+
+// parseRateLimit parses a rate limit header such as
+// "Remaining: 394 Time until reset: 3589".
+func parseRateLimit(v string) (remaining int, reset time.Duration, ok bool) {
+	if v == "" {
+		return 0, 0, false
+	}
+	var seconds int
+	if _, err := fmt.Sscanf(v, "Remaining: %d Time until reset: %d", &remaining, &seconds); err != nil {
+		return 0, 0, false
+	}
+	return remaining, time.Duration(seconds) * time.Second, true
+}
@@ -0,0 +1,124 @@
+package prompingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(t *testing.T, collector *Collector, handler http.HandlerFunc) *pingdom.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "my_api_key",
+		BaseURL:    server.URL,
+		Middleware: []pingdom.Middleware{collector.Middleware()},
+	})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector()
+	client := setup(t, collector, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		w.Header().Set("Req-Limit-Long", "Remaining: 71994 Time until reset: 2591989")
+		if r.URL.Path == "/checks/2" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error": {"statuscode": 403, "statusdesc": "Forbidden", "errormessage": "Something went wrong!"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"check": {"id": 1}, "checks": []}`)
+	})
+
+	_, err := client.Checks.List()
+	assert.NoError(t, err)
+	_, err = client.Checks.Read(1)
+	assert.NoError(t, err)
+	_, err = client.Checks.Read(2)
+	assert.Error(t, err)
+
+	expected := `
+# HELP pingdom_client_requests_total Number of requests sent to the Pingdom API.
+# TYPE pingdom_client_requests_total counter
+pingdom_client_requests_total{endpoint="/checks",method="GET",status="200"} 1
+pingdom_client_requests_total{endpoint="/checks/{id}",method="GET",status="200"} 1
+pingdom_client_requests_total{endpoint="/checks/{id}",method="GET",status="403"} 1
+# HELP pingdom_client_errors_total Number of requests to the Pingdom API that failed or returned an error status.
+# TYPE pingdom_client_errors_total counter
+pingdom_client_errors_total{endpoint="/checks/{id}",method="GET",status="403"} 1
+# HELP pingdom_client_rate_limit_remaining Requests remaining in the rate limit window, as last reported by the Pingdom API.
+# TYPE pingdom_client_rate_limit_remaining gauge
+pingdom_client_rate_limit_remaining{window="long"} 71994
+pingdom_client_rate_limit_remaining{window="short"} 394
+# HELP pingdom_client_rate_limit_reset_seconds Seconds until the rate limit window resets, as last reported by the Pingdom API.
+# TYPE pingdom_client_rate_limit_reset_seconds gauge
+pingdom_client_rate_limit_reset_seconds{window="long"} 2.591989e+06
+pingdom_client_rate_limit_reset_seconds{window="short"} 3589
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"pingdom_client_requests_total",
+		"pingdom_client_errors_total",
+		"pingdom_client_rate_limit_remaining",
+		"pingdom_client_rate_limit_reset_seconds",
+	))
+	assert.Equal(t, 2, testutil.CollectAndCount(collector, "pingdom_client_request_duration_seconds"))
+}
+
+func TestCollectorTransportError(t *testing.T) {
+	collector := NewCollector(WithNamespace("tooling"), WithConstLabels(prometheus.Labels{"account": "main"}))
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "my_api_key",
+		BaseURL:    "http://127.0.0.1:0",
+		Middleware: []pingdom.Middleware{collector.Middleware()},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Probes.List(nil)
+	assert.Error(t, err)
+
+	expected := `
+# HELP tooling_client_errors_total Number of requests to the Pingdom API that failed or returned an error status.
+# TYPE tooling_client_errors_total counter
+tooling_client_errors_total{account="main",endpoint="/probes",method="GET",status="error"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "tooling_client_errors_total"))
+}
+
+func TestCollectorRegister(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	collector := NewCollector()
+	assert.NoError(t, registry.Register(collector))
+
+	client := setup(t, collector, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"probes": []}`)
+	})
+	_, err := client.Probes.List(nil)
+	assert.NoError(t, err)
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Len(t, families, 2)
+}
+
+func TestParseRateLimit(t *testing.T) {
+	remaining, reset, ok := parseRateLimit("Remaining: 394 Time until reset: 3589")
+	assert.True(t, ok)
+	assert.Equal(t, 394, remaining)
+	assert.Equal(t, 3589*time.Second, reset)
+
+	_, _, ok = parseRateLimit("")
+	assert.False(t, ok)
+
+	_, _, ok = parseRateLimit("garbage")
+	assert.False(t, ok)
+}
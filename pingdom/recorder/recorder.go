@@ -0,0 +1,236 @@
+/*
+Package recorder provides an http.RoundTripper that records Pingdom API
+interactions to a cassette file and replays them later, so tests written
+against the real API can run offline.
+
+Record a session against the real API:
+
+	rec, err := recorder.New("testdata/checks.json", recorder.ModeRecord, nil)
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		HTTPClient: &http.Client{Transport: rec},
+	})
+	// ... use client ...
+	err = rec.Stop() // writes the cassette
+
+and replay it later by constructing the recorder with ModeReplay instead.
+Credentials are redacted before the cassette is written, so cassettes can
+be committed: the Authorization header, the auth query parameter of HTTP
+checks and password keys in request and response bodies, following the
+same rules as the debug logging of pingdom.Client.  Replayed responses
+contain the redacted values, and requests are matched after redaction.
+*/
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Mode determines whether a Recorder talks to the real API.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never sends
+	// requests.  Requests without a matching recorded interaction fail.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests using the underlying transport and records
+	// every interaction, replacing the contents of the cassette on Stop.
+	ModeRecord
+)
+
+// Cassette is the on-disk representation of a recorded session.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+// It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// New returns a Recorder using the cassette at path.  In ModeReplay the
+// cassette must exist.  Requests are sent with transport in ModeRecord; if
+// it is nil, http.DefaultTransport is used.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, transport: transport}
+
+	if mode == ModeReplay {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("recorder: invalid cassette %s: %v", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// Stop finishes the session.  In ModeRecord the recorded interactions are
+// written to the cassette, creating its directory if needed.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(b, '\n'), 0644)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	if req.Body != nil {
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     pingdom.RedactURL(req.URL),
+			Headers: pingdom.RedactHeaders(req.Header),
+			Body:    pingdom.RedactBody(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    pingdom.RedactHeaders(resp.Header),
+			Body:       pingdom.RedactBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// replay returns the first recorded interaction not yet replayed that
+// matches the method, path, query and body of req.  Matching ignores the
+// scheme and host so cassettes can be replayed against any base URL.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !matches(interaction.Request, req, body) {
+			continue
+		}
+		r.replayed[i] = true
+
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Headers.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("recorder: no recorded interaction for %s %s in %s", req.Method, req.URL.RequestURI(), r.path)
+}
+
+// matches reports whether req is the recorded request.  Credentials in req
+// are redacted as they were when it was recorded.
+func matches(recorded Request, req *http.Request, body []byte) bool {
+	if recorded.Method != req.Method || recorded.Body != pingdom.RedactBody(body) {
+		return false
+	}
+	u, err := req.URL.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	redactedURL, err := url.Parse(pingdom.RedactURL(req.URL))
+	if err != nil {
+		return false
+	}
+	return u.RequestURI() == redactedURL.RequestURI()
+}
+
+// readBody reads and closes the body of req.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return ioutil.ReadAll(req.Body)
+}
@@ -0,0 +1,167 @@
+package recorder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method == "PUT" {
+			fmt.Fprintf(w, `{"message": "Modification of check was successful!"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"check": {"id": 1, "name": "Check %d"}}`, calls)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cassette := filepath.Join(tempDir(t), "testdata", "cassette.json")
+
+	rec, err := New(cassette, ModeRecord, nil)
+	assert.NoError(t, err)
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "secret_token",
+		BaseURL:    server.URL,
+		HTTPClient: &http.Client{Transport: rec},
+	})
+
+	first, err := client.Checks.Read(1)
+	assert.NoError(t, err)
+	_, err = client.Checks.Update(1, &pingdom.PingCheck{Name: "Renamed", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+	second, err := client.Checks.Read(1)
+	assert.NoError(t, err)
+	assert.NoError(t, rec.Stop())
+	assert.Equal(t, 3, calls)
+
+	b, err := ioutil.ReadFile(cassette)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "secret_token")
+	assert.Contains(t, string(b), pingdom.Redacted)
+
+	// Replay against a different base URL without a server.
+	rec, err = New(cassette, ModeReplay, nil)
+	assert.NoError(t, err)
+	client, _ = pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "other_token",
+		BaseURL:    "http://pingdom.invalid",
+		HTTPClient: &http.Client{Transport: rec},
+	})
+
+	replayedFirst, err := client.Checks.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, first, replayedFirst)
+	_, err = client.Checks.Update(1, &pingdom.PingCheck{Name: "Renamed", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+	replayedSecond, err := client.Checks.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, second, replayedSecond)
+	assert.Equal(t, 3, calls)
+
+	_, err = client.Checks.Read(1)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "no recorded interaction for GET /checks/1"))
+}
+
+func TestRecordRedactsCheckCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "admin:hunter2", r.URL.Query().Get("auth"))
+		fmt.Fprint(w, `{"check": {"id": 1, "name": "Web", "type": {"http": {"username": "admin", "password": "hunter2"}}}}`)
+	}))
+	defer server.Close()
+
+	check := &pingdom.HttpCheck{
+		Name:       "Web",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Username:   "admin",
+		Password:   "hunter2",
+	}
+	cassette := filepath.Join(tempDir(t), "cassette.json")
+
+	rec, err := New(cassette, ModeRecord, nil)
+	assert.NoError(t, err)
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "secret_token",
+		BaseURL:    server.URL,
+		HTTPClient: &http.Client{Transport: rec},
+	})
+	created, err := client.Checks.Create(check)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+	assert.NoError(t, rec.Stop())
+
+	b, err := ioutil.ReadFile(cassette)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "hunter2")
+	assert.NotContains(t, string(b), "secret_token")
+	assert.Contains(t, string(b), "auth=REDACTED")
+	assert.Contains(t, string(b), `\"password\":\"REDACTED\"`)
+
+	// The same request, with the real credentials, matches on replay.
+	rec, err = New(cassette, ModeReplay, nil)
+	assert.NoError(t, err)
+	client, _ = pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "other_token",
+		BaseURL:    "http://pingdom.invalid",
+		HTTPClient: &http.Client{Transport: rec},
+	})
+	replayed, err := client.Checks.Create(check)
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, replayed.ID)
+	assert.Equal(t, "REDACTED", replayed.Type.HTTP.Password)
+}
+
+func TestReplayRequiresMatchingBody(t *testing.T) {
+	cassette := filepath.Join(tempDir(t), "cassette.json")
+	err := ioutil.WriteFile(cassette, []byte(`{
+		"interactions": [
+			{
+				"request": {"method": "POST", "url": "https://api.pingdom.com/api/3.1/alerting/teams", "body": "{\"member_ids\":[],\"name\":\"Ops\"}"},
+				"response": {"status_code": 200, "body": "{\"team\": {\"id\": 7, \"name\": \"Ops\"}}"}
+			}
+		]
+	}`), 0644)
+	assert.NoError(t, err)
+
+	rec, err := New(cassette, ModeReplay, nil)
+	assert.NoError(t, err)
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   "token",
+		HTTPClient: &http.Client{Transport: rec},
+	})
+
+	_, err = client.Teams.Create(&pingdom.Team{Name: "Dev", MemberIDs: []int{}})
+	assert.Error(t, err)
+
+	team, err := client.Teams.Create(&pingdom.Team{Name: "Ops", MemberIDs: []int{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, team.ID)
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(tempDir(t), "missing.json"), ModeReplay, nil)
+	assert.Error(t, err)
+}
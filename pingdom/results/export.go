@@ -0,0 +1,167 @@
+/*
+Package results walks the raw results of Pingdom checks over a time range and
+exports them as CSV or JSON Lines.
+
+	e := results.New(results.Config{
+		Checks: client.Checks,
+		Probes: client.Probes,
+		Format: results.CSV,
+	})
+	err := e.Export(os.Stdout, time.Now().Add(-7*24*time.Hour), time.Now(), 12345, 67890)
+
+Results are written as they are fetched, so exports of long ranges do not
+need to fit in memory.  Use Iterator to process the pages of results
+directly.
+*/
+package results
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Format is the output format of an Exporter.
+type Format int
+
+const (
+	// CSV writes a header line followed by a line per result.
+	CSV Format = iota
+
+	// JSONLines writes a JSON object per line for each result.
+	JSONLines
+)
+
+// columns are the CSV header, in the same order as the fields of Record.
+var columns = []string{
+	"check_id", "time", "probe_id", "probe_name", "probe_country",
+	"status", "response_time", "status_desc", "status_desc_long",
+}
+
+// Record is an exported result.  It is the format of each line of JSON Lines
+// output.
+type Record struct {
+	CheckID        int       `json:"check_id"`
+	Time           time.Time `json:"time"`
+	ProbeID        int       `json:"probe_id"`
+	ProbeName      string    `json:"probe_name"`
+	ProbeCountry   string    `json:"probe_country"`
+	Status         string    `json:"status"`
+	ResponseTime   int       `json:"response_time"`
+	StatusDesc     string    `json:"status_desc"`
+	StatusDescLong string    `json:"status_desc_long"`
+}
+
+func (r *Record) csv() []string {
+	return []string{
+		strconv.Itoa(r.CheckID),
+		r.Time.Format(time.RFC3339),
+		strconv.Itoa(r.ProbeID),
+		r.ProbeName,
+		r.ProbeCountry,
+		r.Status,
+		strconv.Itoa(r.ResponseTime),
+		r.StatusDesc,
+		r.StatusDescLong,
+	}
+}
+
+// Config configures an Exporter.
+type Config struct {
+	// Checks is used to fetch results.  It is usually the Checks service
+	// of a pingdom.Client.
+	Checks pingdom.CheckAPI
+
+	// Probes, if set, is used to add the name and country of the probe to
+	// each result.
+	Probes pingdom.ProbeAPI
+
+	Format Format
+
+	// Params are passed with every request for results, as described for
+	// NewIterator.
+	Params map[string]string
+}
+
+// Exporter writes the results of checks.
+type Exporter struct {
+	config Config
+}
+
+// New returns an Exporter with the given configuration.
+func New(config Config) *Exporter {
+	return &Exporter{config: config}
+}
+
+// Export writes the results of the checks between from and to.  Checks are
+// exported in the given order and the results of each check newest first.
+func (e *Exporter) Export(w io.Writer, from, to time.Time, checkIDs ...int) error {
+	if e.config.Format != CSV && e.config.Format != JSONLines {
+		return fmt.Errorf("results: unknown format %d", e.config.Format)
+	}
+
+	probes := map[int]pingdom.ProbeResponse{}
+	if e.config.Probes != nil {
+		list, err := e.config.Probes.List()
+		if err != nil {
+			return err
+		}
+		for _, p := range list {
+			probes[p.ID] = p
+		}
+	}
+
+	var write func(*Record) error
+	var flush func() error
+	switch e.config.Format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+		write = func(r *Record) error { return cw.Write(r.csv()) }
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case JSONLines:
+		enc := json.NewEncoder(w)
+		write = func(r *Record) error { return enc.Encode(r) }
+		flush = func() error { return nil }
+	}
+
+	for _, id := range checkIDs {
+		it := NewIterator(e.config.Checks, id, from, to, e.config.Params)
+		for it.Next() {
+			for _, result := range it.Page().Results {
+				probe := probes[result.ProbeID]
+				err := write(&Record{
+					CheckID:        id,
+					Time:           time.Unix(int64(result.Time), 0).UTC(),
+					ProbeID:        result.ProbeID,
+					ProbeName:      probe.Name,
+					ProbeCountry:   probe.Country,
+					Status:         result.Status,
+					ResponseTime:   result.ResponseTime,
+					StatusDesc:     result.StatusDesc,
+					StatusDescLong: result.StatusDescLong,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,57 @@
+package results
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_CSV(t *testing.T) {
+	server, id := newServer(t)
+	defer server.Close()
+	server.AddProbes(pingdom.ProbeResponse{ID: 1, Name: "Stockholm, Sweden", Country: "Sweden", Active: true})
+	server.AddResults(id,
+		pingdom.Result{ProbeID: 1, Time: 1000, Status: "up", ResponseTime: 120, StatusDesc: "OK", StatusDescLong: "OK"},
+		pingdom.Result{ProbeID: 2, Time: 1060, Status: "down", StatusDesc: "Timeout", StatusDescLong: "Timeout, \"no answer\""},
+	)
+	client := server.Client()
+
+	var buf bytes.Buffer
+	e := New(Config{Checks: client.Checks, Probes: client.Probes})
+	err := e.Export(&buf, time.Unix(0, 0), time.Unix(2000, 0), id)
+	assert.NoError(t, err)
+
+	idStr := strconv.Itoa(id)
+	assert.Equal(t, "check_id,time,probe_id,probe_name,probe_country,status,response_time,status_desc,status_desc_long\n"+
+		idStr+",1970-01-01T00:17:40Z,2,,,down,0,Timeout,\"Timeout, \"\"no answer\"\"\"\n"+
+		idStr+",1970-01-01T00:16:40Z,1,\"Stockholm, Sweden\",Sweden,up,120,OK,OK\n", buf.String())
+}
+
+func TestExport_JSONLines(t *testing.T) {
+	server, id := newServer(t)
+	defer server.Close()
+	other, err := server.Client().Checks.Create(&pingdom.PingCheck{Name: "ping", Hostname: "example.com", Resolution: 1})
+	assert.NoError(t, err)
+	server.AddResults(id, pingdom.Result{ProbeID: 1, Time: 1000, Status: "up", ResponseTime: 120})
+	server.AddResults(other.ID, pingdom.Result{ProbeID: 1, Time: 1000, Status: "down"})
+
+	var buf bytes.Buffer
+	e := New(Config{Checks: server.Client().Checks, Format: JSONLines})
+	err = e.Export(&buf, time.Unix(0, 0), time.Unix(2000, 0), other.ID, id)
+	assert.NoError(t, err)
+
+	assert.Equal(t,
+		`{"check_id":`+strconv.Itoa(other.ID)+`,"time":"1970-01-01T00:16:40Z","probe_id":1,"probe_name":"","probe_country":"","status":"down","response_time":0,"status_desc":"","status_desc_long":""}`+"\n"+
+			`{"check_id":`+strconv.Itoa(id)+`,"time":"1970-01-01T00:16:40Z","probe_id":1,"probe_name":"","probe_country":"","status":"up","response_time":120,"status_desc":"","status_desc_long":""}`+"\n",
+		buf.String())
+}
+
+func TestExport_UnknownFormat(t *testing.T) {
+	e := New(Config{Format: Format(7)})
+	err := e.Export(&bytes.Buffer{}, time.Unix(0, 0), time.Unix(2000, 0), 1)
+	assert.EqualError(t, err, "results: unknown format 7")
+}
@@ -0,0 +1,140 @@
+package results
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// maxLimit is the largest number of results the API returns per request.
+const maxLimit = 1000
+
+// Iterator walks the results of a check over a time range one page at a
+// time, newest first.  The API returns at most 1000 results per request, so
+// the range is split into consecutive requests, each ending at the time of
+// the oldest result of the previous one.  Results at that time are returned
+// only once.
+//
+//	it := results.NewIterator(client.Checks, 12345, from, to, nil)
+//	for it.Next() {
+//		for _, r := range it.Page().Results {
+//			fmt.Println(r.Time, r.Status)
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type Iterator struct {
+	checks  pingdom.CheckAPI
+	checkID int
+	from    int64
+	to      int64
+	params  map[string]string
+	limit   int
+
+	page *pingdom.ResultsResponse
+	seen map[pingdom.Result]bool
+	done bool
+	err  error
+}
+
+// NewIterator returns an Iterator over the results of a check between from
+// and to, inclusive.  Params are passed with every request, e.g. to only
+// return results of some probes or with some status.  A "limit" parameter
+// sets the page size, which defaults to and cannot exceed 1000; "from",
+// "to" and "offset" are ignored.
+func NewIterator(checks pingdom.CheckAPI, checkID int, from, to time.Time, params map[string]string) *Iterator {
+	it := &Iterator{
+		checks:  checks,
+		checkID: checkID,
+		from:    from.Unix(),
+		to:      to.Unix(),
+		params:  map[string]string{},
+		limit:   maxLimit,
+	}
+	for k, v := range params {
+		switch k {
+		case "from", "to", "offset":
+		case "limit":
+			if n, err := strconv.Atoi(v); err == nil && n > 0 && n < maxLimit {
+				it.limit = n
+			}
+		default:
+			it.params[k] = v
+		}
+	}
+	it.params["limit"] = strconv.Itoa(it.limit)
+	it.done = it.from > it.to
+	return it
+}
+
+// Next fetches the next page of results.  It returns false when the range
+// is exhausted or an error occurs.
+func (it *Iterator) Next() bool {
+	for !it.done {
+		it.params["from"] = strconv.FormatInt(it.from, 10)
+		it.params["to"] = strconv.FormatInt(it.to, 10)
+		resp, err := it.checks.Results(it.checkID, it.params)
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+
+		page := &pingdom.ResultsResponse{ActiveProbes: resp.ActiveProbes}
+		for _, r := range resp.Results {
+			if !it.seen[r] {
+				page.Results = append(page.Results, r)
+			}
+		}
+		it.advance(resp.Results)
+
+		if len(page.Results) > 0 {
+			it.page = page
+			return true
+		}
+	}
+	it.page = nil
+	return false
+}
+
+// advance moves the end of the range to the oldest of the results just
+// fetched and remembers the results at that time, which the next request
+// returns again.
+func (it *Iterator) advance(fetched []pingdom.Result) {
+	if len(fetched) < it.limit {
+		it.done = true
+		return
+	}
+
+	oldest := int64(fetched[0].Time)
+	for _, r := range fetched {
+		if int64(r.Time) < oldest {
+			oldest = int64(r.Time)
+		}
+	}
+	if oldest >= it.to {
+		// A full page of results at the same time.  Skip to the previous
+		// second rather than requesting the same page forever.
+		oldest = it.to - 1
+	}
+	it.seen = map[pingdom.Result]bool{}
+	for _, r := range fetched {
+		if int64(r.Time) == oldest {
+			it.seen[r] = true
+		}
+	}
+	it.to = oldest
+	it.done = it.to < it.from
+}
+
+// Page returns the page fetched by the last call to Next.
+func (it *Iterator) Page() *pingdom.ResultsResponse {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,98 @@
+package results
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdomtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newServer returns a fake server with an HTTP check and its ID.
+func newServer(t *testing.T) (*pingdomtest.Server, int) {
+	server := pingdomtest.NewServer("token")
+	check, err := server.Client().Checks.Create(&pingdom.HttpCheck{Name: "web", Hostname: "example.com", Resolution: 1})
+	assert.NoError(t, err)
+	return server, check.ID
+}
+
+func TestIterator(t *testing.T) {
+	server, id := newServer(t)
+	defer server.Close()
+
+	// Three probes report every 60 seconds, so each time has three results
+	// and pages of 4 results split them.
+	var seeded []pingdom.Result
+	for i := 0; i < 10; i++ {
+		for probe := 1; probe <= 3; probe++ {
+			seeded = append(seeded, pingdom.Result{ProbeID: probe, Time: 1000 + 60*i, Status: "up", ResponseTime: i})
+		}
+	}
+	server.AddResults(id, seeded...)
+
+	it := NewIterator(server.Client().Checks, id, time.Unix(1060, 0), time.Unix(1480, 0), map[string]string{"limit": "4", "probes": "1,2,3"})
+	var got []pingdom.Result
+	pages := 0
+	for it.Next() {
+		pages++
+		got = append(got, it.Page().Results...)
+	}
+	assert.NoError(t, it.Err())
+	assert.Greater(t, pages, 1)
+
+	assert.Len(t, got, 24)
+	seen := map[pingdom.Result]bool{}
+	for i, r := range got {
+		assert.False(t, seen[r], "duplicate result %v", r)
+		seen[r] = true
+		if i > 0 {
+			assert.LessOrEqual(t, r.Time, got[i-1].Time)
+		}
+	}
+	assert.Equal(t, 1480, got[0].Time)
+	assert.Equal(t, 1060, got[len(got)-1].Time)
+}
+
+func TestIterator_SameTime(t *testing.T) {
+	server, id := newServer(t)
+	defer server.Close()
+
+	for probe := 1; probe <= 5; probe++ {
+		server.AddResults(id, pingdom.Result{ProbeID: probe, Time: 2000, Status: "up"})
+	}
+	server.AddResults(id, pingdom.Result{ProbeID: 1, Time: 1000, Status: "down"})
+
+	it := NewIterator(server.Client().Checks, id, time.Unix(0, 0), time.Unix(3000, 0), map[string]string{"limit": "2"})
+	var got []pingdom.Result
+	for it.Next() {
+		got = append(got, it.Page().Results...)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 1000, got[len(got)-1].Time, "iteration should get past a full page of results at the same time")
+}
+
+func TestIterator_Empty(t *testing.T) {
+	server, id := newServer(t)
+	defer server.Close()
+
+	it := NewIterator(server.Client().Checks, id, time.Unix(0, 0), time.Unix(3000, 0), nil)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Nil(t, it.Page())
+}
+
+func TestIterator_Error(t *testing.T) {
+	failure := errors.New("failure")
+	mock := &pingdommock.CheckAPI{
+		ResultsFunc: func(id int, params ...map[string]string) (*pingdom.ResultsResponse, error) {
+			return nil, failure
+		},
+	}
+
+	it := NewIterator(mock, 1, time.Unix(0, 0), time.Unix(3000, 0), nil)
+	assert.False(t, it.Next())
+	assert.Equal(t, failure, it.Err())
+}
@@ -0,0 +1,244 @@
+/*
+Package stats computes response time and error statistics from the raw
+results of Pingdom checks.
+
+	acc := stats.NewAccumulator(stats.Config{Bucket: time.Hour})
+	it := results.NewIterator(client.Checks, 12345, from, to, nil)
+	if err := acc.AddIterator(12345, it); err != nil {
+		log.Fatal(err)
+	}
+	for _, check := range acc.Report() {
+		log.Printf("check %d: p95 %.0fms, %.2f%% errors", check.CheckID, check.P95, 100*check.ErrorRatio)
+	}
+
+Response time statistics only use results with status "up", as the response
+time of failed results is usually that of a timeout.  Every other status
+counts as an error.
+*/
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/results"
+)
+
+// statusUp is the status of successful results.
+const statusUp = "up"
+
+// Stats summarizes a set of results.  Response times are in milliseconds.
+type Stats struct {
+	// Count is the number of results and Errors the number of those with
+	// a status other than "up".
+	Count      int
+	Errors     int
+	ErrorRatio float64
+
+	// The response time statistics are zero if there are no successful
+	// results.  Percentiles are interpolated linearly between the closest
+	// ranks.
+	Min    int
+	Max    int
+	Mean   float64
+	StdDev float64
+	P50    float64
+	P90    float64
+	P95    float64
+	P99    float64
+}
+
+// Compute returns the statistics of results.
+func Compute(rs []pingdom.Result) Stats {
+	s := Stats{Count: len(rs)}
+	var times []float64
+	for _, r := range rs {
+		if r.Status != statusUp {
+			s.Errors++
+			continue
+		}
+		times = append(times, float64(r.ResponseTime))
+	}
+	if s.Count > 0 {
+		s.ErrorRatio = float64(s.Errors) / float64(s.Count)
+	}
+	if len(times) == 0 {
+		return s
+	}
+
+	sort.Float64s(times)
+	s.Min = int(times[0])
+	s.Max = int(times[len(times)-1])
+
+	var sum float64
+	for _, t := range times {
+		sum += t
+	}
+	s.Mean = sum / float64(len(times))
+	var squares float64
+	for _, t := range times {
+		squares += (t - s.Mean) * (t - s.Mean)
+	}
+	s.StdDev = math.Sqrt(squares / float64(len(times)))
+
+	s.P50 = percentile(times, 0.50)
+	s.P90 = percentile(times, 0.90)
+	s.P95 = percentile(times, 0.95)
+	s.P99 = percentile(times, 0.99)
+	return s
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating
+// linearly between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// Bucket is the statistics of the results in a time interval.
+type Bucket struct {
+	Start time.Time
+	End   time.Time
+	Stats
+}
+
+// CheckStats is the statistics of the results of a check.
+type CheckStats struct {
+	CheckID int
+
+	// Stats is computed over the results of all probes.
+	Stats
+
+	// Probes are the statistics of the results of each probe, by probe
+	// ID.
+	Probes map[int]Stats
+
+	// Buckets are the statistics over consecutive intervals of
+	// Config.Bucket, oldest first.  Intervals without results are left
+	// out.  Buckets is nil if Config.Bucket is zero.
+	Buckets []Bucket
+}
+
+// Config configures an Accumulator.
+type Config struct {
+	// From and To, if set, restrict the statistics to results in the
+	// window between them, inclusive.
+	From time.Time
+	To   time.Time
+
+	// Bucket is the length of the intervals the results are downsampled
+	// to, rounded down to whole seconds.  Intervals are aligned to the Unix
+	// epoch, so buckets of an hour start at the top of each hour.
+	Bucket time.Duration
+}
+
+// Accumulator collects the results of checks, in any order, and computes
+// their statistics.  Its methods must not be called concurrently.
+type Accumulator struct {
+	config  Config
+	results map[int][]pingdom.Result
+}
+
+// NewAccumulator returns an Accumulator with the given configuration.
+func NewAccumulator(config Config) *Accumulator {
+	return &Accumulator{config: config, results: map[int][]pingdom.Result{}}
+}
+
+// Add adds results of a check.
+func (a *Accumulator) Add(checkID int, rs ...pingdom.Result) {
+	for _, r := range rs {
+		t := time.Unix(int64(r.Time), 0)
+		if !a.config.From.IsZero() && t.Before(a.config.From) {
+			continue
+		}
+		if !a.config.To.IsZero() && t.After(a.config.To) {
+			continue
+		}
+		a.results[checkID] = append(a.results[checkID], r)
+	}
+}
+
+// AddPage adds a page of results of a check, as returned by
+// CheckService.Results.
+func (a *Accumulator) AddPage(checkID int, page *pingdom.ResultsResponse) {
+	a.Add(checkID, page.Results...)
+}
+
+// AddIterator adds every page of results returned by it, which must iterate
+// over the results of the given check.
+func (a *Accumulator) AddIterator(checkID int, it *results.Iterator) error {
+	for it.Next() {
+		a.AddPage(checkID, it.Page())
+	}
+	return it.Err()
+}
+
+// Report returns the statistics of each check with results, ordered by
+// check ID.
+func (a *Accumulator) Report() []CheckStats {
+	ids := make([]int, 0, len(a.results))
+	for id := range a.results {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	report := make([]CheckStats, len(ids))
+	for i, id := range ids {
+		report[i] = a.check(id)
+	}
+	return report
+}
+
+func (a *Accumulator) check(id int) CheckStats {
+	rs := a.results[id]
+	cs := CheckStats{
+		CheckID: id,
+		Stats:   Compute(rs),
+		Probes:  map[int]Stats{},
+	}
+
+	byProbe := map[int][]pingdom.Result{}
+	for _, r := range rs {
+		byProbe[r.ProbeID] = append(byProbe[r.ProbeID], r)
+	}
+	for probe, probeResults := range byProbe {
+		cs.Probes[probe] = Compute(probeResults)
+	}
+
+	if a.config.Bucket <= 0 {
+		return cs
+	}
+	size := int64(a.config.Bucket / time.Second)
+	if size < 1 {
+		size = 1
+	}
+	byBucket := map[int64][]pingdom.Result{}
+	for _, r := range rs {
+		start := int64(r.Time) - mod(int64(r.Time), size)
+		byBucket[start] = append(byBucket[start], r)
+	}
+	starts := make([]int64, 0, len(byBucket))
+	for start := range byBucket {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	cs.Buckets = make([]Bucket, len(starts))
+	for i, start := range starts {
+		t := time.Unix(start, 0).UTC()
+		cs.Buckets[i] = Bucket{Start: t, End: t.Add(time.Duration(size) * time.Second), Stats: Compute(byBucket[start])}
+	}
+	return cs
+}
+
+// mod returns a modulo b, which is never negative.
+func mod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
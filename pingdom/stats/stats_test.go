@@ -0,0 +1,115 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/russellcardullo/go-pingdom/pingdom/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func up(probe, t, responseTime int) pingdom.Result {
+	return pingdom.Result{ProbeID: probe, Time: t, Status: "up", ResponseTime: responseTime}
+}
+
+func down(probe, t int) pingdom.Result {
+	return pingdom.Result{ProbeID: probe, Time: t, Status: "down", ResponseTime: 30000}
+}
+
+func TestCompute(t *testing.T) {
+	var rs []pingdom.Result
+	for i := 1; i <= 100; i++ {
+		rs = append(rs, up(1, i, i*10))
+	}
+	rs = append(rs, down(1, 101), pingdom.Result{ProbeID: 1, Time: 102, Status: "unconfirmed_down"})
+
+	s := Compute(rs)
+
+	assert.Equal(t, 102, s.Count)
+	assert.Equal(t, 2, s.Errors)
+	assert.InDelta(t, 2.0/102, s.ErrorRatio, 1e-9)
+	assert.Equal(t, 10, s.Min)
+	assert.Equal(t, 1000, s.Max)
+	assert.InDelta(t, 505, s.Mean, 1e-9)
+	assert.InDelta(t, 288.6607, s.StdDev, 1e-4)
+	assert.InDelta(t, 505, s.P50, 1e-9)
+	assert.InDelta(t, 901, s.P90, 1e-9)
+	assert.InDelta(t, 950.5, s.P95, 1e-9)
+	assert.InDelta(t, 990.1, s.P99, 1e-9)
+}
+
+func TestCompute_NoSuccess(t *testing.T) {
+	s := Compute([]pingdom.Result{down(1, 1)})
+	assert.Equal(t, Stats{Count: 1, Errors: 1, ErrorRatio: 1}, s)
+
+	assert.Equal(t, Stats{}, Compute(nil))
+}
+
+func TestAccumulator(t *testing.T) {
+	acc := NewAccumulator(Config{
+		From:   time.Unix(3600, 0),
+		To:     time.Unix(3*3600, 0),
+		Bucket: time.Hour,
+	})
+	acc.Add(2, up(1, 3600, 100))
+	acc.AddPage(1, &pingdom.ResultsResponse{Results: []pingdom.Result{
+		up(1, 3599, 5000),
+		up(1, 3600, 100),
+		up(2, 3700, 300),
+		down(2, 7300),
+		up(1, 7400, 200),
+		up(1, 3*3600+1, 5000),
+	}})
+
+	report := acc.Report()
+
+	if !assert.Len(t, report, 2) {
+		return
+	}
+	check := report[0]
+	assert.Equal(t, 1, check.CheckID)
+	assert.Equal(t, 4, check.Count)
+	assert.Equal(t, 1, check.Errors)
+	assert.Equal(t, 100, check.Min)
+	assert.Equal(t, 300, check.Max)
+
+	assert.Equal(t, 2, check.Probes[1].Count)
+	assert.InDelta(t, 150, check.Probes[1].Mean, 1e-9)
+	assert.Equal(t, 0.5, check.Probes[2].ErrorRatio)
+
+	if assert.Len(t, check.Buckets, 2) {
+		assert.Equal(t, time.Unix(3600, 0).UTC(), check.Buckets[0].Start)
+		assert.Equal(t, time.Unix(7200, 0).UTC(), check.Buckets[0].End)
+		assert.Equal(t, 2, check.Buckets[0].Count)
+		assert.InDelta(t, 200, check.Buckets[0].Mean, 1e-9)
+		assert.Equal(t, time.Unix(7200, 0).UTC(), check.Buckets[1].Start)
+		assert.Equal(t, 0.5, check.Buckets[1].ErrorRatio)
+	}
+
+	assert.Equal(t, 2, report[1].CheckID)
+}
+
+func TestAccumulator_NoBuckets(t *testing.T) {
+	acc := NewAccumulator(Config{})
+	acc.Add(1, up(1, 10, 100))
+
+	report := acc.Report()
+	assert.Nil(t, report[0].Buckets)
+}
+
+func TestAccumulator_AddIterator(t *testing.T) {
+	mock := &pingdommock.CheckAPI{
+		ResultsFunc: func(id int, params ...map[string]string) (*pingdom.ResultsResponse, error) {
+			return &pingdom.ResultsResponse{Results: []pingdom.Result{up(1, 20, 100), up(1, 10, 300)}}, nil
+		},
+	}
+	acc := NewAccumulator(Config{})
+
+	err := acc.AddIterator(7, results.NewIterator(mock, 7, time.Unix(0, 0), time.Unix(100, 0), nil))
+	assert.NoError(t, err)
+	report := acc.Report()
+	assert.Equal(t, 7, report[0].CheckID)
+	assert.InDelta(t, 200, report[0].Mean, 1e-9)
+}
@@ -0,0 +1,130 @@
+package pingdom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxTagLength is the longest tag accepted by the API.
+const maxTagLength = 64
+
+// Tags is a set of check tags.  NewTags, ParseTags and the operations on
+// Tags return sorted sets without duplicates, but the methods also accept
+// Tags built from a literal or a conversion.  Use String to set the Tags field of
+// HttpCheck, PingCheck or TCPCheck, and CheckResponse.TagSet to read the
+// tags of a check:
+//
+//	check := pingdom.HttpCheck{
+//		Name:     "Example",
+//		Hostname: "example.com",
+//		Tags:     pingdom.NewTags("web", "production").String(),
+//	}
+type Tags []string
+
+// NewTags returns the set of the given tags, ignoring empty and duplicate
+// tags.
+func NewTags(tags ...string) Tags {
+	return Tags(nil).Add(tags...)
+}
+
+// ParseTags parses tags in the comma-separated format sent to the API.
+func ParseTags(s string) Tags {
+	return NewTags(strings.Split(s, ",")...)
+}
+
+// String returns the tags in the comma-separated format sent to the API.
+func (t Tags) String() string {
+	return strings.Join(t, ",")
+}
+
+// Has reports whether tag is in the set.
+func (t Tags) Has(tag string) bool {
+	for _, tt := range t {
+		if tt == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Add returns a new set with the given tags added.
+func (t Tags) Add(tags ...string) Tags {
+	set := map[string]bool{}
+	for _, tag := range t {
+		set[tag] = true
+	}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			set[tag] = true
+		}
+	}
+	return sortedTags(set)
+}
+
+// Remove returns a new set with the given tags removed.
+func (t Tags) Remove(tags ...string) Tags {
+	set := map[string]bool{}
+	for _, tag := range t {
+		set[tag] = true
+	}
+	for _, tag := range tags {
+		delete(set, strings.TrimSpace(tag))
+	}
+	return sortedTags(set)
+}
+
+// Equal reports whether both sets contain the same tags, in any order.
+func (t Tags) Equal(other Tags) bool {
+	a, b := NewTags(t...), NewTags(other...)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Valid determines whether every tag can be sent to the API.
+func (t Tags) Valid() error {
+	for _, tag := range t {
+		if err := validTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("Invalid value for tag.  Must contain non-empty string")
+	}
+	if strings.Contains(tag, ",") {
+		return fmt.Errorf("Invalid value %q for tag.  Must not contain commas", tag)
+	}
+	if len(tag) > maxTagLength {
+		return fmt.Errorf("Invalid value %q for tag.  Must not be longer than %d characters", tag, maxTagLength)
+	}
+	return nil
+}
+
+func sortedTags(set map[string]bool) Tags {
+	tags := make(Tags, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// TagSet returns the names of the tags of the check.
+func (cr *CheckResponse) TagSet() Tags {
+	names := make([]string, len(cr.Tags))
+	for i, tag := range cr.Tags {
+		names[i] = tag.Name
+	}
+	return NewTags(names...)
+}
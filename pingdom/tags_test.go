@@ -0,0 +1,54 @@
+package pingdom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	tags := NewTags("web", "api", "web", " ", "db")
+	assert.Equal(t, Tags{"api", "db", "web"}, tags)
+	assert.Equal(t, "api,db,web", tags.String())
+	assert.True(t, tags.Has("db"))
+	assert.False(t, tags.Has("cache"))
+
+	assert.Equal(t, Tags{"api", "cache", "db", "web"}, tags.Add("cache", "api"))
+	assert.Equal(t, Tags{"web"}, tags.Remove("api", "db", "missing"))
+	assert.Equal(t, Tags{"api", "db", "web"}, tags, "operations must not modify the receiver")
+
+	assert.True(t, tags.Equal(ParseTags("web, db,api")))
+	assert.False(t, tags.Equal(ParseTags("web")))
+	assert.Equal(t, Tags{}, ParseTags(""))
+	assert.Equal(t, "", Tags(nil).String())
+}
+
+func TestTagsUnsorted(t *testing.T) {
+	tags := Tags{"web", "api"}
+	assert.True(t, tags.Has("web"))
+	assert.True(t, tags.Has("api"))
+	assert.False(t, tags.Has("db"))
+	assert.True(t, tags.Equal(Tags{"api", "web"}))
+	assert.True(t, tags.Equal(Tags{"web", "api", "web"}))
+	assert.False(t, tags.Equal(Tags{"web", "db"}))
+	assert.Equal(t, Tags{"api"}, tags.Remove("web"))
+}
+
+func TestTagsValid(t *testing.T) {
+	assert.NoError(t, NewTags("web", "production").Valid())
+	assert.Error(t, Tags{""}.Valid())
+	assert.Error(t, Tags{"a,b"}.Valid())
+	assert.Error(t, Tags{strings.Repeat("x", 65)}.Valid())
+}
+
+func TestCheckResponseTagSet(t *testing.T) {
+	cr := &CheckResponse{Tags: []CheckResponseTag{
+		{Name: "web", Type: "u", Count: 2},
+		{Name: "api", Type: "a", Count: "1"},
+	}}
+	assert.Equal(t, Tags{"api", "web"}, cr.TagSet())
+
+	check := HttpCheck{Name: "Example", Hostname: "example.com", Resolution: 5, Tags: cr.TagSet().String()}
+	assert.Equal(t, "api,web", check.PutParams()["tags"])
+}
@@ -2,6 +2,7 @@ package pingdom
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"strconv"
 )
@@ -17,6 +18,22 @@ type TeamAPI interface {
 	Valid() error
 }
 
+// TeamServiceAPI is the set of operations provided by TeamService.  Code
+// that accepts a TeamServiceAPI rather than a *TeamService can be tested
+// with a mock.
+type TeamServiceAPI interface {
+	List() ([]TeamResponse, error)
+	Read(id int) (*TeamResponse, error)
+	Create(team TeamAPI) (*TeamResponse, error)
+	Update(id int, team TeamAPI) (*TeamResponse, error)
+	Delete(id int) (*TeamDeleteResponse, error)
+	AddMembers(id int, contactIDs ...int) (*TeamResponse, error)
+	RemoveMembers(id int, contactIDs ...int) (*TeamResponse, error)
+	SetMembers(id int, contactIDs ...int) (*TeamResponse, error)
+}
+
+var _ TeamServiceAPI = (*TeamService)(nil)
+
 // List return a list of teams from Pingdom.
 func (cs *TeamService) List() ([]TeamResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/alerting/teams", nil)
@@ -24,7 +41,7 @@ func (cs *TeamService) List() ([]TeamResponse, error) {
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +97,10 @@ func (cs *TeamService) Create(team TeamAPI) (*TeamResponse, error) {
 
 // Update is used to update existing team.
 func (cs *TeamService) Update(id int, team TeamAPI) (*TeamResponse, error) {
+	if err := team.Valid(); err != nil {
+		return nil, err
+	}
+
 	req, err := cs.client.NewJSONRequest("PUT", "/alerting/teams/"+strconv.Itoa(id), team.RenderForJSONAPI())
 	if err != nil {
 		return nil, err
@@ -107,3 +128,114 @@ func (cs *TeamService) Delete(id int) (*TeamDeleteResponse, error) {
 	}
 	return t, err
 }
+
+// AddMembers adds contacts to a team, keeping its current members.  Contacts
+// that are already members are ignored.
+func (cs *TeamService) AddMembers(id int, contactIDs ...int) (*TeamResponse, error) {
+	return cs.modifyMembers(id, contactIDs, func(members []int) []int {
+		for _, contactID := range contactIDs {
+			if !containsInt(members, contactID) {
+				members = append(members, contactID)
+			}
+		}
+		return members
+	})
+}
+
+// RemoveMembers removes contacts from a team.  Contacts that are not members
+// are ignored.  The contacts are not required to exist, so the IDs of deleted
+// contacts that are still listed as members can be removed.
+func (cs *TeamService) RemoveMembers(id int, contactIDs ...int) (*TeamResponse, error) {
+	return cs.modifyMembers(id, nil, func(members []int) []int {
+		kept := []int{}
+		for _, member := range members {
+			if !containsInt(contactIDs, member) {
+				kept = append(kept, member)
+			}
+		}
+		return kept
+	})
+}
+
+// SetMembers replaces the members of a team with the given contacts.
+func (cs *TeamService) SetMembers(id int, contactIDs ...int) (*TeamResponse, error) {
+	return cs.modifyMembers(id, contactIDs, func([]int) []int {
+		members := []int{}
+		for _, contactID := range contactIDs {
+			if !containsInt(members, contactID) {
+				members = append(members, contactID)
+			}
+		}
+		return members
+	})
+}
+
+// modifyMembers reads a team, checks that the contacts referenced by added
+// exist and writes back the members returned by fn.  The team is returned as
+// read if its members are unchanged.
+func (cs *TeamService) modifyMembers(id int, added []int, fn func([]int) []int) (*TeamResponse, error) {
+	current, err := cs.Read(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.checkContacts(added); err != nil {
+		return nil, err
+	}
+
+	team := current.Team()
+	members := fn(append([]int{}, team.MemberIDs...))
+	if equalInts(members, team.MemberIDs) {
+		return current, nil
+	}
+	team.MemberIDs = members
+	return cs.Update(id, team)
+}
+
+// checkContacts returns ErrUnknownContact if any of the IDs does not refer to
+// an existing contact.
+func (cs *TeamService) checkContacts(contactIDs []int) error {
+	if len(contactIDs) == 0 {
+		return nil
+	}
+
+	contacts, err := cs.client.Contacts.List()
+	if err != nil {
+		return err
+	}
+	known := map[int]bool{}
+	for _, c := range contacts {
+		known[c.ID] = true
+	}
+
+	var unknown []int
+	for _, contactID := range contactIDs {
+		if !known[contactID] {
+			unknown = append(unknown, contactID)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %v", ErrUnknownContact, unknown)
+	}
+	return nil
+}
+
+func containsInt(list []int, i int) bool {
+	for _, v := range list {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
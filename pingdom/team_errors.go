@@ -0,0 +1,7 @@
+package pingdom
+
+import "errors"
+
+// ErrUnknownContact is an error for when a team member refers to a contact
+// that does not exist.
+var ErrUnknownContact = errors.New("unknown contact")
@@ -1,6 +1,8 @@
 package pingdom
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -226,3 +228,140 @@ func TestTeamServiceDelete(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, team, "Teams.Delete() should return correct result")
 }
+
+// handleMembers serves team 65 with members 1 and 2, contacts 1 to 4 and
+// records the member IDs of PUT requests in put.
+func handleMembers(t *testing.T, put *[]int) {
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "A"}, {"id": 2, "name": "B"}, {"id": 3, "name": "C"}, {"id": 4, "name": "D"}]}`)
+	})
+	mux.HandleFunc("/alerting/teams/65", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"team": {"id": 65, "name": "Operations", "members": [
+				{"id": 1, "name": "A", "type": "contact"},
+				{"id": 2, "name": "B", "type": "contact"}
+			]}}`)
+		case "PUT":
+			body := struct {
+				Name      string `json:"name"`
+				MemberIDs []int  `json:"member_ids"`
+			}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Operations", body.Name)
+			*put = body.MemberIDs
+			fmt.Fprint(w, `{"team": {"id": 65, "name": "Operations"}}`)
+		default:
+			t.Errorf("Unexpected request method: %v", r.Method)
+		}
+	})
+}
+
+func TestTeamServiceAddMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put []int
+	handleMembers(t, &put)
+
+	team, err := client.Teams.AddMembers(65, 3, 1, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 65, team.ID)
+	assert.Equal(t, []int{1, 2, 3, 4}, put)
+}
+
+func TestTeamServiceAddMembers_Unchanged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put []int
+	handleMembers(t, &put)
+
+	team, err := client.Teams.AddMembers(65, 2)
+	assert.NoError(t, err)
+	assert.Nil(t, put, "Team should not be updated")
+	assert.Len(t, team.Members, 2)
+}
+
+func TestTeamServiceAddMembers_UnknownContact(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put []int
+	handleMembers(t, &put)
+
+	_, err := client.Teams.AddMembers(65, 3, 7, 9)
+	assert.True(t, errors.Is(err, ErrUnknownContact), "got %v", err)
+	assert.EqualError(t, err, "unknown contact: [7 9]")
+	assert.Nil(t, put)
+}
+
+func TestTeamServiceRemoveMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put []int
+	handleMembers(t, &put)
+
+	_, err := client.Teams.RemoveMembers(65, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, put)
+
+	put = nil
+	_, err = client.Teams.RemoveMembers(65, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, put)
+}
+
+func TestTeamServiceRemoveMembers_DeletedContact(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put []int
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Contacts should not be listed to remove members")
+	})
+	mux.HandleFunc("/alerting/teams/65", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"team": {"id": 65, "name": "Operations", "members": [
+				{"id": 1, "name": "A", "type": "contact"},
+				{"id": 5, "name": "Deleted", "type": "contact"}
+			]}}`)
+		case "PUT":
+			body := struct {
+				MemberIDs []int `json:"member_ids"`
+			}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			put = body.MemberIDs
+			fmt.Fprint(w, `{"team": {"id": 65, "name": "Operations"}}`)
+		default:
+			t.Errorf("Unexpected request method: %v", r.Method)
+		}
+	})
+
+	_, err := client.Teams.RemoveMembers(65, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, put)
+}
+
+func TestTeamServiceSetMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var put []int
+	handleMembers(t, &put)
+
+	_, err := client.Teams.SetMembers(65, 4, 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 3}, put)
+}
+
+func TestTeamServiceUpdate_Invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Teams.Update(65, &Team{Name: ""})
+	assert.Error(t, err)
+}
@@ -29,5 +29,30 @@ func (t *Team) Valid() error {
 		return fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string")
 	}
 
+	seen := map[int]bool{}
+	for _, id := range t.MemberIDs {
+		if id <= 0 {
+			return fmt.Errorf("Invalid value %d for `MemberIDs`.  Must contain positive contact IDs", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("Invalid value %d for `MemberIDs`.  Must not contain duplicate contact IDs", id)
+		}
+		seen[id] = true
+	}
+
 	return nil
 }
+
+// Team returns the Team that, submitted with TeamService.Update, leaves the
+// team unchanged.  Members are kept in order.
+func (tr *TeamResponse) Team() *Team {
+	t := &Team{
+		ID:        tr.ID,
+		Name:      tr.Name,
+		MemberIDs: make([]int, len(tr.Members)),
+	}
+	for i, m := range tr.Members {
+		t.MemberIDs[i] = m.ID
+	}
+	return t
+}
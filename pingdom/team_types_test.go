@@ -25,3 +25,27 @@ func TestTeamNotValid(t *testing.T) {
 
 	assert.NotEqual(t, nil, params, "Team.Valid() should return not nil if not valid")
 }
+
+func TestTeamNotValid_MemberIDs(t *testing.T) {
+	team := Team{Name: "fake team", MemberIDs: []int{1, 0}}
+	assert.EqualError(t, team.Valid(), "Invalid value 0 for `MemberIDs`.  Must contain positive contact IDs")
+
+	team = Team{Name: "fake team", MemberIDs: []int{1, 3, 1}}
+	assert.EqualError(t, team.Valid(), "Invalid value 1 for `MemberIDs`.  Must not contain duplicate contact IDs")
+}
+
+func TestTeamResponseTeam(t *testing.T) {
+	tr := TeamResponse{
+		ID:   65,
+		Name: "Operations",
+		Members: []TeamMemberResponse{
+			{ID: 10043154, Name: "Templeton Peck", Type: "contact"},
+			{ID: 10034512, Name: "John Smith", Type: "contact"},
+		},
+	}
+
+	assert.Equal(t, &Team{ID: 65, Name: "Operations", MemberIDs: []int{10043154, 10034512}}, tr.Team())
+
+	empty := (&TeamResponse{ID: 66, Name: "Empty"}).Team()
+	assert.Equal(t, `{"member_ids":[],"name":"Empty"}`, empty.RenderForJSONAPI())
+}
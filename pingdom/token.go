@@ -0,0 +1,143 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoToken is an error for when a TokenSource has no API token to give.
+var ErrNoToken = errors.New("no API token")
+
+// TokenSource supplies the API token sent with each request.  It is called
+// once per request, possibly from several goroutines at once, so a long
+// running program picks up a rotated token without being restarted.
+//
+// A TokenSource is configured with ClientConfig.TokenSource or replaced on a
+// running client with Client.SetTokenSource:
+//
+//	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+//		TokenSource: pingdom.FileToken("/run/secrets/pingdom_api_token"),
+//	})
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// TokenSourceFunc is an adapter to use an ordinary function, such as one
+// reading a secret manager, as a TokenSource.
+type TokenSourceFunc func() (string, error)
+
+// Token calls f.
+func (f TokenSourceFunc) Token() (string, error) {
+	return f()
+}
+
+type staticToken string
+
+// StaticToken returns a TokenSource that always returns token.
+func StaticToken(token string) TokenSource {
+	return staticToken(token)
+}
+
+func (t staticToken) Token() (string, error) {
+	if t == "" {
+		return "", ErrNoToken
+	}
+	return string(t), nil
+}
+
+type envToken string
+
+// EnvToken returns a TokenSource that reads the environment variable name on
+// every call.  Name defaults to PINGDOM_API_TOKEN.
+func EnvToken(name string) TokenSource {
+	if name == "" {
+		name = "PINGDOM_API_TOKEN"
+	}
+	return envToken(name)
+}
+
+func (name envToken) Token() (string, error) {
+	token := os.Getenv(string(name))
+	if token == "" {
+		return "", fmt.Errorf("%w: %s is not set", ErrNoToken, string(name))
+	}
+	return token, nil
+}
+
+// fileToken caches the token read from a file until the file changes.
+type fileToken struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// FileToken returns a TokenSource that reads the token from the file at
+// path, such as a mounted secret.  Leading and trailing whitespace is
+// removed.  The file is read again whenever its modification time or size
+// changes.  If the file cannot be read or is empty, such as while a secret is
+// being replaced, the last token read is returned; an error is returned only
+// if no token has been read yet.
+func FileToken(path string) TokenSource {
+	return &fileToken{path: path}
+}
+
+func (f *fileToken) Token() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return f.cached(err)
+	}
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.cached(err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return f.cached(fmt.Errorf("%w: %s is empty", ErrNoToken, f.path))
+	}
+	f.token = token
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return token, nil
+}
+
+// cached returns the last token read, or err if there is none.
+func (f *fileToken) cached(err error) (string, error) {
+	if f.token == "" {
+		return "", err
+	}
+	return f.token, nil
+}
+
+// SetTokenSource replaces the TokenSource of the client.  It is safe to call
+// while requests are being made.  Requests made with a nil TokenSource use
+// APIToken.
+func (pc *Client) SetTokenSource(ts TokenSource) {
+	pc.tokenMu.Lock()
+	defer pc.tokenMu.Unlock()
+	pc.tokenSource = ts
+}
+
+// token returns the API token for a new request.
+func (pc *Client) token() (string, error) {
+	pc.tokenMu.RLock()
+	ts := pc.tokenSource
+	pc.tokenMu.RUnlock()
+	if ts == nil {
+		return pc.APIToken, nil
+	}
+	return ts.Token()
+}
@@ -0,0 +1,154 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticToken(t *testing.T) {
+	token, err := StaticToken("key").Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "key", token)
+
+	_, err = StaticToken("").Token()
+	assert.ErrorIs(t, err, ErrNoToken)
+}
+
+func TestEnvToken(t *testing.T) {
+	ts := EnvToken("")
+	t.Setenv("PINGDOM_API_TOKEN", "first")
+	token, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", token)
+
+	t.Setenv("PINGDOM_API_TOKEN", "second")
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+
+	_, err = EnvToken("PINGDOM_TEST_UNSET_TOKEN").Token()
+	assert.ErrorIs(t, err, ErrNoToken)
+	assert.EqualError(t, err, "no API token: PINGDOM_TEST_UNSET_TOKEN is not set")
+}
+
+func TestFileToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	ts := FileToken(path)
+
+	_, err := ts.Token()
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	assert.NoError(t, os.WriteFile(path, []byte("first\n"), 0600))
+	token, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", token)
+
+	// Rotate the token, making sure the modification time changes.
+	assert.NoError(t, os.WriteFile(path, []byte("second\n"), 0600))
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(path, later, later))
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+
+	// The cached token is used while the file is missing.
+	assert.NoError(t, os.Remove(path))
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+
+	// And while the file is truncated before being written again.
+	assert.NoError(t, os.WriteFile(path, []byte(" \n"), 0600))
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	assert.NoError(t, os.WriteFile(empty, []byte(" \n"), 0600))
+	_, err = FileToken(empty).Token()
+	assert.ErrorIs(t, err, ErrNoToken)
+}
+
+func TestNewRequestUsesTokenSource(t *testing.T) {
+	calls := 0
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "ignored",
+		TokenSource: TokenSourceFunc(func() (string, error) {
+			calls++
+			return fmt.Sprintf("token%d", calls), nil
+		}),
+	})
+	assert.NoError(t, err)
+
+	req, err := c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token1", req.Header.Get("Authorization"))
+
+	req, err = c.NewJSONRequest("POST", "/checks", "{}")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token2", req.Header.Get("Authorization"))
+}
+
+func TestNewRequestTokenSourceError(t *testing.T) {
+	failure := errors.New("secret manager unavailable")
+	c, err := NewClientWithConfig(ClientConfig{
+		TokenSource: TokenSourceFunc(func() (string, error) { return "", failure }),
+	})
+	assert.NoError(t, err)
+
+	_, err = c.NewRequest("GET", "/checks", nil)
+	assert.ErrorIs(t, err, failure)
+	_, err = c.NewJSONRequest("POST", "/checks", "{}")
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestSetTokenSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var tokens []string
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		mu.Unlock()
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	_, err := client.Checks.List()
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Checks.List()
+			assert.NoError(t, err)
+		}()
+	}
+	client.SetTokenSource(StaticToken("rotated"))
+	wg.Wait()
+
+	_, err = client.Checks.List()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Bearer my_api_key", tokens[0])
+	assert.Equal(t, "Bearer rotated", tokens[len(tokens)-1])
+	for _, token := range tokens {
+		assert.Contains(t, []string{"Bearer my_api_key", "Bearer rotated"}, token)
+	}
+
+	client.SetTokenSource(nil)
+	req, err := client.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer my_api_key", req.Header.Get("Authorization"))
+}
@@ -0,0 +1,294 @@
+/*
+Package watcher polls Pingdom checks and reports changes in their status.
+
+	w := watcher.New(watcher.Config{Checks: client.Checks, Interval: time.Minute})
+	err := w.Run(ctx, func(e watcher.Event) {
+		if e.Type == watcher.CheckDown {
+			log.Printf("%s is down", e.Check.Name)
+		}
+	})
+
+The first poll records the current state of the checks without reporting
+any events.  Later polls are compared with the previous one.  A check that
+went down and recovered between two polls still has a newer LastErrorTime;
+this is reported as a CheckDown event followed by a CheckUp event.
+*/
+package watcher
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+const (
+	defaultInterval   = time.Minute
+	defaultMaxBackoff = 15 * time.Minute
+)
+
+// EventType is the kind of change reported by an Event.
+type EventType int
+
+const (
+	// CheckAdded is reported for checks that were not in the previous
+	// poll.
+	CheckAdded EventType = iota + 1
+
+	// CheckRemoved is reported for checks that are no longer returned.
+	CheckRemoved
+
+	// CheckDown is reported when a check goes down, or when a check that
+	// is up had an error since the previous poll.
+	CheckDown
+
+	// CheckUp is reported when a check recovers or is resumed while up.
+	CheckUp
+
+	// CheckPaused is reported when a check is paused.
+	CheckPaused
+)
+
+var eventTypeNames = map[EventType]string{
+	CheckAdded:   "CheckAdded",
+	CheckRemoved: "CheckRemoved",
+	CheckDown:    "CheckDown",
+	CheckUp:      "CheckUp",
+	CheckPaused:  "CheckPaused",
+}
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return "EventType(unknown)"
+}
+
+// Event is a change in a check between two polls.
+type Event struct {
+	Type EventType
+
+	// Check is the current state of the check, or its last known state
+	// for CheckRemoved events.
+	Check pingdom.CheckResponse
+
+	// Previous is the state of the check in the previous poll.  It is nil
+	// for CheckAdded events.
+	Previous *pingdom.CheckResponse
+
+	// Time is when the poll that detected the change completed.
+	Time time.Time
+}
+
+// Config configures a Watcher.
+type Config struct {
+	// Checks is used to list checks.  It is usually the Checks service of a
+	// pingdom.Client.
+	Checks pingdom.CheckAPI
+
+	// Params are passed to Checks.List, e.g. to only watch checks with
+	// certain tags.
+	Params map[string]string
+
+	// Interval is the time between polls.  It defaults to one minute.
+	Interval time.Duration
+
+	// Jitter is the maximum random delay added to each interval so that
+	// several watchers do not poll at the same time.
+	Jitter time.Duration
+
+	// MaxBackoff is the longest delay between polls after consecutive
+	// errors.  The delay doubles with each error, starting at Interval.  It
+	// defaults to 15 minutes.
+	MaxBackoff time.Duration
+
+	// OnError, if set, is called with errors returned by Checks.List.  The
+	// watcher keeps polling after errors.
+	OnError func(error)
+}
+
+// Watcher polls checks and reports changes.  Its methods must not be called
+// concurrently.
+type Watcher struct {
+	config Config
+	now    func() time.Time
+
+	rand     *rand.Rand
+	previous map[int]pingdom.CheckResponse
+}
+
+// New returns a Watcher with the given configuration.
+func New(config Config) *Watcher {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.MaxBackoff < config.Interval {
+		config.MaxBackoff = config.Interval
+	}
+	return &Watcher{
+		config: config,
+		now:    time.Now,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Poll lists the checks once and returns the changes since the previous
+// poll, ordered by check ID.  The first poll returns no events.
+func (w *Watcher) Poll() ([]Event, error) {
+	var params []map[string]string
+	if w.config.Params != nil {
+		params = append(params, w.config.Params)
+	}
+	checks, err := w.config.Checks.List(params...)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[int]pingdom.CheckResponse, len(checks))
+	for _, check := range checks {
+		current[check.ID] = check
+	}
+
+	previous := w.previous
+	w.previous = current
+	if previous == nil {
+		return nil, nil
+	}
+	return diff(previous, current, w.now()), nil
+}
+
+// Run polls the checks until ctx is done, calling handle with each event.
+// It returns nil once ctx is done.
+func (w *Watcher) Run(ctx context.Context, handle func(Event)) error {
+	failures := 0
+	for {
+		events, err := w.Poll()
+		if err != nil {
+			failures++
+			if w.config.OnError != nil {
+				w.config.OnError(err)
+			}
+		} else {
+			failures = 0
+		}
+		for _, event := range events {
+			if ctx.Err() != nil {
+				return nil
+			}
+			handle(event)
+		}
+
+		timer := time.NewTimer(w.delay(failures))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// Watch polls the checks in a new goroutine until ctx is done and sends the
+// events to the returned channel, which is closed when the watcher stops.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		w.Run(ctx, func(e Event) {
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
+
+// delay returns the time to wait before the next poll after the given
+// number of consecutive failures.
+func (w *Watcher) delay(failures int) time.Duration {
+	d := w.config.Interval
+	for i := 0; i < failures && d < w.config.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > w.config.MaxBackoff {
+		d = w.config.MaxBackoff
+	}
+	if w.config.Jitter > 0 {
+		d += time.Duration(w.rand.Int63n(int64(w.config.Jitter)))
+	}
+	return d
+}
+
+// diff returns the events between two snapshots of checks.
+func diff(previous, current map[int]pingdom.CheckResponse, now time.Time) []Event {
+	var events []Event
+	for _, id := range sortedIDs(previous, current) {
+		before, existed := previous[id]
+		after, exists := current[id]
+		switch {
+		case !existed:
+			events = append(events, Event{Type: CheckAdded, Check: after, Time: now})
+		case !exists:
+			events = append(events, Event{Type: CheckRemoved, Check: before, Previous: &before, Time: now})
+		default:
+			for _, t := range changes(before, after) {
+				prev := before
+				events = append(events, Event{Type: t, Check: after, Previous: &prev, Time: now})
+			}
+		}
+	}
+	return events
+}
+
+// changes returns the event types for a check present in both snapshots.
+func changes(before, after pingdom.CheckResponse) []EventType {
+	if isPaused(after) {
+		if !isPaused(before) {
+			return []EventType{CheckPaused}
+		}
+		return nil
+	}
+
+	wasDown := !isPaused(before) && before.Status == "down"
+	newError := after.LastErrorTime > before.LastErrorTime
+	switch after.Status {
+	case "down":
+		if !wasDown {
+			return []EventType{CheckDown}
+		}
+	case "up":
+		if wasDown || isPaused(before) {
+			return []EventType{CheckUp}
+		}
+		if newError {
+			return []EventType{CheckDown, CheckUp}
+		}
+	}
+	return nil
+}
+
+func isPaused(check pingdom.CheckResponse) bool {
+	return check.Paused || check.Status == "paused"
+}
+
+func sortedIDs(snapshots ...map[int]pingdom.CheckResponse) []int {
+	seen := map[int]bool{}
+	var ids []int
+	for _, snapshot := range snapshots {
+		for id := range snapshot {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
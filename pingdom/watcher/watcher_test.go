@@ -0,0 +1,206 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdom/pingdommock"
+	"github.com/stretchr/testify/assert"
+)
+
+// snapshots returns a mock returning each snapshot in turn, repeating the
+// last one.
+func snapshots(lists ...[]pingdom.CheckResponse) *pingdommock.CheckAPI {
+	var mu sync.Mutex
+	i := 0
+	return &pingdommock.CheckAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			list := lists[i]
+			if i < len(lists)-1 {
+				i++
+			}
+			return list, nil
+		},
+	}
+}
+
+func types(events []Event) []EventType {
+	var ts []EventType
+	for _, e := range events {
+		ts = append(ts, e.Type)
+	}
+	return ts
+}
+
+func TestPoll(t *testing.T) {
+	mock := snapshots(
+		[]pingdom.CheckResponse{
+			{ID: 1, Name: "web", Status: "up"},
+			{ID: 2, Name: "api", Status: "up"},
+			{ID: 3, Name: "db", Status: "down"},
+		},
+		[]pingdom.CheckResponse{
+			{ID: 1, Name: "web", Status: "down", LastErrorTime: 100},
+			{ID: 3, Name: "db", Status: "up", LastErrorTime: 90},
+			{ID: 4, Name: "cdn", Status: "up"},
+		},
+		[]pingdom.CheckResponse{
+			{ID: 1, Name: "web", Status: "paused", Paused: true, LastErrorTime: 100},
+			{ID: 3, Name: "db", Status: "up", LastErrorTime: 90},
+			{ID: 4, Name: "cdn", Status: "up"},
+		},
+		[]pingdom.CheckResponse{
+			{ID: 1, Name: "web", Status: "up", LastErrorTime: 100},
+			{ID: 3, Name: "db", Status: "up", LastErrorTime: 150},
+			{ID: 4, Name: "cdn", Status: "up"},
+		},
+	)
+	w := New(Config{Checks: mock, Params: map[string]string{"tags": "prod"}})
+	now := time.Unix(1600000000, 0)
+	w.now = func() time.Time { return now }
+
+	events, err := w.Poll()
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = w.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, []EventType{CheckDown, CheckRemoved, CheckUp, CheckAdded}, types(events))
+	assert.Equal(t, 1, events[0].Check.ID)
+	assert.Equal(t, "up", events[0].Previous.Status)
+	assert.Equal(t, now, events[0].Time)
+	assert.Equal(t, "api", events[1].Check.Name)
+	assert.Equal(t, 3, events[2].Check.ID)
+	assert.Nil(t, events[3].Previous)
+	assert.Equal(t, 4, events[3].Check.ID)
+
+	events, err = w.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, []EventType{CheckPaused}, types(events))
+
+	events, err = w.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, []EventType{CheckUp, CheckDown, CheckUp}, types(events))
+	assert.Equal(t, []int{1, 3, 3}, []int{events[0].Check.ID, events[1].Check.ID, events[2].Check.ID})
+
+	events, err = w.Poll()
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	for _, call := range mock.Calls() {
+		assert.Equal(t, []interface{}{[]map[string]string{{"tags": "prod"}}}, call.Args)
+	}
+}
+
+func TestPollStillDown(t *testing.T) {
+	w := New(Config{Checks: snapshots(
+		[]pingdom.CheckResponse{{ID: 1, Status: "down", LastErrorTime: 100}},
+		[]pingdom.CheckResponse{{ID: 1, Status: "down", LastErrorTime: 160}},
+	)})
+
+	_, err := w.Poll()
+	assert.NoError(t, err)
+	events, err := w.Poll()
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestPollError(t *testing.T) {
+	w := New(Config{Checks: &pingdommock.CheckAPI{}})
+	events, err := w.Poll()
+	assert.ErrorIs(t, err, pingdommock.ErrNotMocked)
+	assert.Empty(t, events)
+}
+
+func TestDelay(t *testing.T) {
+	w := New(Config{Interval: time.Second, MaxBackoff: 5 * time.Second})
+	assert.Equal(t, time.Second, w.delay(0))
+	assert.Equal(t, 2*time.Second, w.delay(1))
+	assert.Equal(t, 4*time.Second, w.delay(2))
+	assert.Equal(t, 5*time.Second, w.delay(3))
+	assert.Equal(t, 5*time.Second, w.delay(100))
+
+	w = New(Config{Interval: time.Second, Jitter: 500 * time.Millisecond})
+	for i := 0; i < 100; i++ {
+		d := w.delay(0)
+		assert.True(t, d >= time.Second && d < 1500*time.Millisecond, d)
+	}
+}
+
+func TestEventTypeString(t *testing.T) {
+	assert.Equal(t, "CheckDown", CheckDown.String())
+	assert.Equal(t, "EventType(unknown)", EventType(0).String())
+}
+
+func TestRun(t *testing.T) {
+	w := New(Config{
+		Checks: snapshots(
+			[]pingdom.CheckResponse{{ID: 1, Status: "up"}},
+			[]pingdom.CheckResponse{{ID: 1, Status: "down"}},
+		),
+		Interval: time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var events []Event
+	err := w.Run(ctx, func(e Event) {
+		events = append(events, e)
+		cancel()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []EventType{CheckDown}, types(events))
+}
+
+func TestRunBacksOffOnErrors(t *testing.T) {
+	failures := 0
+	mock := &pingdommock.CheckAPI{
+		ListFunc: func(params ...map[string]string) ([]pingdom.CheckResponse, error) {
+			return nil, errors.New("unavailable")
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := New(Config{
+		Checks:   mock,
+		Interval: time.Millisecond,
+		OnError: func(err error) {
+			assert.EqualError(t, err, "unavailable")
+			failures++
+			if failures == 3 {
+				cancel()
+			}
+		},
+	})
+
+	assert.NoError(t, w.Run(ctx, func(Event) { t.Fatal("unexpected event") }))
+	assert.Equal(t, 3, failures)
+	assert.Len(t, mock.Calls(), 3)
+}
+
+func TestWatch(t *testing.T) {
+	w := New(Config{
+		Checks: snapshots(
+			[]pingdom.CheckResponse{{ID: 1, Status: "up"}},
+			[]pingdom.CheckResponse{{ID: 1, Status: "up"}, {ID: 2, Status: "up"}},
+		),
+		Interval: time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := w.Watch(ctx)
+	e := <-ch
+	assert.Equal(t, CheckAdded, e.Type)
+	assert.Equal(t, 2, e.Check.ID)
+
+	cancel()
+	for range ch {
+	}
+}
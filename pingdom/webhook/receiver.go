@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// maxPayloadSize is the largest webhook body accepted by a Receiver.
+const maxPayloadSize = 1 << 20
+
+// AlertHandler handles a decoded alert.  Returning an error makes the
+// Receiver respond with 500 Internal Server Error.
+type AlertHandler func(ctx context.Context, alert *Alert) error
+
+// Receiver is an http.Handler that decodes webhooks and dispatches them to
+// the registered handlers.  Invalid payloads are rejected with 400 Bad
+// Request.  Handlers may be registered while the Receiver is serving.
+type Receiver struct {
+	mu       sync.RWMutex
+	handlers []AlertHandler
+	byType   map[string][]AlertHandler
+}
+
+// NewReceiver returns a Receiver without any handlers.
+func NewReceiver() *Receiver {
+	return &Receiver{byType: map[string][]AlertHandler{}}
+}
+
+// Handle registers a handler for alerts of every check type.
+func (r *Receiver) Handle(h AlertHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, h)
+}
+
+// HandleType registers a handler for alerts of a check type such as
+// TypeHTTP.
+func (r *Receiver) HandleType(checkType string, h AlertHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byType[checkType] = append(r.byType[checkType], h)
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	alert, err := Decode(http.MaxBytesReader(w, req.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := r.Dispatch(req.Context(), alert); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Dispatch calls the handlers registered for the alert, those for its check
+// type after those for every type.  It stops at the first handler returning
+// an error.
+func (r *Receiver) Dispatch(ctx context.Context, alert *Alert) error {
+	r.mu.RLock()
+	handlers := append(append([]AlertHandler{}, r.handlers...), r.byType[alert.CheckType]...)
+	r.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ctx, alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func post(t *testing.T, h http.Handler, fixture string) *httptest.ResponseRecorder {
+	f, err := os.Open(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/pingdom", f))
+	return rec
+}
+
+func TestReceiverDispatch(t *testing.T) {
+	r := NewReceiver()
+	var got []string
+	r.Handle(func(ctx context.Context, alert *Alert) error {
+		got = append(got, "all:"+alert.CheckType)
+		return nil
+	})
+	r.HandleType(TypeHTTP, func(ctx context.Context, alert *Alert) error {
+		got = append(got, "http:"+alert.HTTP.Hostname)
+		return nil
+	})
+	r.HandleType(TypeDNS, func(ctx context.Context, alert *Alert) error {
+		got = append(got, "dns:"+alert.DNS.Hostname)
+		return nil
+	})
+
+	for _, fixture := range []string{"http.json", "tcp.json", "ping.json", "dns.json", "transaction.json"} {
+		rec := post(t, r, fixture)
+		assert.Equal(t, http.StatusNoContent, rec.Code, fixture)
+	}
+
+	assert.Equal(t, []string{
+		"all:http", "http:www.example.com",
+		"all:tcp",
+		"all:ping",
+		"all:dns", "dns:www.example.com",
+		"all:transaction",
+	}, got)
+}
+
+func TestReceiverHandlerError(t *testing.T) {
+	r := NewReceiver()
+	called := false
+	r.Handle(func(ctx context.Context, alert *Alert) error {
+		return errors.New("queue full")
+	})
+	r.HandleType(TypeHTTP, func(ctx context.Context, alert *Alert) error {
+		called = true
+		return nil
+	})
+
+	rec := post(t, r, "http.json")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "queue full")
+	assert.False(t, called)
+}
+
+func TestReceiverInvalidPayload(t *testing.T) {
+	r := NewReceiver()
+	r.Handle(func(ctx context.Context, alert *Alert) error {
+		t.Fatal("handler called for invalid payload")
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("POST", "/pingdom", strings.NewReader(`{"check_type": "HTTP"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestReceiverMethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewReceiver().ServeHTTP(rec, httptest.NewRequest("GET", "/pingdom", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "POST", rec.Header().Get("Allow"))
+}
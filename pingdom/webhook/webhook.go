@@ -0,0 +1,218 @@
+/*
+Package webhook decodes the alert webhooks Pingdom posts when the state of a
+check changes and dispatches them to handlers.
+
+	receiver := webhook.NewReceiver()
+	receiver.HandleType(webhook.TypeHTTP, func(ctx context.Context, alert *webhook.Alert) error {
+		log.Printf("%s is %s: %s", alert.HTTP.FullURL, alert.CurrentState, alert.Description)
+		return nil
+	})
+	http.Handle("/pingdom", receiver)
+
+Alerts carry the ID of the check and its type as used by
+pingdom.CheckResponse, so they can be matched with the results of
+CheckService.List and CheckService.Read.
+*/
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Check types of alerts, named as in pingdom.CheckResponseType.
+const (
+	TypeHTTP        = "http"
+	TypeHTTPCustom  = "httpcustom"
+	TypeTCP         = "tcp"
+	TypeUDP         = "udp"
+	TypePing        = "ping"
+	TypeDNS         = "dns"
+	TypeSMTP        = "smtp"
+	TypePOP3        = "pop3"
+	TypeIMAP        = "imap"
+	TypeTransaction = "transaction"
+)
+
+// checkTypes maps the check types used in webhook payloads to the names used
+// by the rest of the API.
+var checkTypes = map[string]string{
+	"HTTP":        TypeHTTP,
+	"HTTP_CUSTOM": TypeHTTPCustom,
+	"PORT_TCP":    TypeTCP,
+	"PORT_UDP":    TypeUDP,
+	"PING":        TypePing,
+	"DNS":         TypeDNS,
+	"SMTP":        TypeSMTP,
+	"POP3":        TypePOP3,
+	"IMAP":        TypeIMAP,
+	"TRANSACTION": TypeTransaction,
+}
+
+// States reported for uptime checks and transaction checks.
+const (
+	StateUp      = "UP"
+	StateDown    = "DOWN"
+	StateSuccess = "SUCCESS"
+	StateFailing = "FAILING"
+)
+
+// Alert is a decoded webhook payload.
+type Alert struct {
+	CheckID   int
+	CheckName string
+
+	// CheckType is the type of the check as used by
+	// pingdom.CheckResponseType, e.g. "http" or "tcp".
+	CheckType string
+
+	Tags            []string
+	PreviousState   string
+	CurrentState    string
+	ImportanceLevel string
+	StateChanged    time.Time
+	Description     string
+	LongDescription string
+	CustomMessage   string
+
+	// ErrorMessage is only set for transaction checks.
+	ErrorMessage string
+
+	FirstProbe  *Probe
+	SecondProbe *Probe
+
+	// The parameters of the check.  Only the field matching CheckType is
+	// set.
+	HTTP *HTTPParams
+	TCP  *TCPParams
+	Ping *PingParams
+	DNS  *DNSParams
+}
+
+// Down reports whether the check is down or failing.
+func (a *Alert) Down() bool {
+	return a.CurrentState == StateDown || a.CurrentState == StateFailing
+}
+
+// Probe is a probe server that confirmed a state change.
+type Probe struct {
+	IP       string `json:"ip"`
+	IPv6     string `json:"ipv6"`
+	Location string `json:"location"`
+	Version  int    `json:"version,omitempty"`
+}
+
+// HTTPParams are the parameters of HTTP and custom HTTP checks.
+type HTTPParams struct {
+	BasicAuth  bool   `json:"basic_auth"`
+	Encryption bool   `json:"encryption"`
+	FullURL    string `json:"full_url"`
+	Header     string `json:"header"`
+	Hostname   string `json:"hostname"`
+	IPv6       bool   `json:"ipv6"`
+	Port       int    `json:"port"`
+	URL        string `json:"url"`
+}
+
+// TCPParams are the parameters of TCP and UDP checks.
+type TCPParams struct {
+	Hostname       string `json:"hostname"`
+	IPv6           bool   `json:"ipv6"`
+	Port           int    `json:"port"`
+	StringToSend   string `json:"string_to_send,omitempty"`
+	StringToExpect string `json:"string_to_expect,omitempty"`
+}
+
+// PingParams are the parameters of ping checks.
+type PingParams struct {
+	Hostname string `json:"hostname"`
+	IPv6     bool   `json:"ipv6"`
+}
+
+// DNSParams are the parameters of DNS checks.
+type DNSParams struct {
+	Hostname   string `json:"hostname"`
+	ExpectedIP string `json:"expected_ip"`
+	Nameserver string `json:"nameserver"`
+}
+
+// payload is the JSON body of a webhook.
+type payload struct {
+	CheckID               int             `json:"check_id"`
+	CheckName             string          `json:"check_name"`
+	CheckType             string          `json:"check_type"`
+	CheckParams           json.RawMessage `json:"check_params"`
+	Tags                  []string        `json:"tags"`
+	PreviousState         string          `json:"previous_state"`
+	CurrentState          string          `json:"current_state"`
+	ImportanceLevel       string          `json:"importance_level"`
+	StateChangedTimestamp int64           `json:"state_changed_timestamp"`
+	Description           string          `json:"description"`
+	LongDescription       string          `json:"long_description"`
+	CustomMessage         string          `json:"custom_message"`
+	ErrorMessage          string          `json:"error_message"`
+	FirstProbe            *Probe          `json:"first_probe"`
+	SecondProbe           *Probe          `json:"second_probe"`
+}
+
+// Decode reads and validates a webhook payload.
+func Decode(r io.Reader) (*Alert, error) {
+	p := &payload{}
+	if err := json.NewDecoder(r).Decode(p); err != nil {
+		return nil, fmt.Errorf("webhook: invalid payload: %v", err)
+	}
+
+	checkType, ok := checkTypes[p.CheckType]
+	if !ok {
+		return nil, fmt.Errorf("webhook: unknown check type %q", p.CheckType)
+	}
+	if p.CheckID <= 0 {
+		return nil, fmt.Errorf("webhook: invalid check ID %d", p.CheckID)
+	}
+	if p.CurrentState == "" {
+		return nil, fmt.Errorf("webhook: missing current state")
+	}
+
+	a := &Alert{
+		CheckID:         p.CheckID,
+		CheckName:       p.CheckName,
+		CheckType:       checkType,
+		Tags:            p.Tags,
+		PreviousState:   p.PreviousState,
+		CurrentState:    p.CurrentState,
+		ImportanceLevel: p.ImportanceLevel,
+		Description:     p.Description,
+		LongDescription: p.LongDescription,
+		CustomMessage:   p.CustomMessage,
+		ErrorMessage:    p.ErrorMessage,
+		FirstProbe:      p.FirstProbe,
+		SecondProbe:     p.SecondProbe,
+	}
+	if p.StateChangedTimestamp != 0 {
+		a.StateChanged = time.Unix(p.StateChangedTimestamp, 0).UTC()
+	}
+
+	var params interface{}
+	switch checkType {
+	case TypeHTTP, TypeHTTPCustom:
+		a.HTTP = &HTTPParams{}
+		params = a.HTTP
+	case TypeTCP, TypeUDP:
+		a.TCP = &TCPParams{}
+		params = a.TCP
+	case TypePing:
+		a.Ping = &PingParams{}
+		params = a.Ping
+	case TypeDNS:
+		a.DNS = &DNSParams{}
+		params = a.DNS
+	}
+	if params != nil && len(p.CheckParams) > 0 && string(p.CheckParams) != "null" {
+		if err := json.Unmarshal(p.CheckParams, params); err != nil {
+			return nil, fmt.Errorf("webhook: invalid check_params for %s check: %v", p.CheckType, err)
+		}
+	}
+	return a, nil
+}
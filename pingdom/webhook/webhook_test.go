@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeFixture(t *testing.T, name string) *Alert {
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	alert, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return alert
+}
+
+func TestDecodeHTTP(t *testing.T) {
+	alert := decodeFixture(t, "http.json")
+
+	assert.Equal(t, &Alert{
+		CheckID:         12345,
+		CheckName:       "Name of HTTP check",
+		CheckType:       TypeHTTP,
+		Tags:            []string{"example_tag"},
+		PreviousState:   StateUp,
+		CurrentState:    StateDown,
+		ImportanceLevel: "HIGH",
+		StateChanged:    time.Date(2016, 1, 1, 1, 1, 1, 0, time.UTC),
+		Description:     "Short error message",
+		LongDescription: "Long error message",
+		FirstProbe:      &Probe{IP: "123.4.5.6", IPv6: "2001:4800:1020:209::5", Location: "Stockholm, Sweden"},
+		SecondProbe:     &Probe{IP: "123.4.5.6", IPv6: "2001:4800:1020:209::5", Location: "Austin, US", Version: 1},
+		HTTP: &HTTPParams{
+			Encryption: true,
+			FullURL:    "https://www.example.com/path",
+			Header:     "User-Agent:Pingdom.com_bot_version_1.4_(http://www.pingdom.com/)",
+			Hostname:   "www.example.com",
+			Port:       443,
+			URL:        "/path",
+		},
+	}, alert)
+	assert.True(t, alert.Down())
+}
+
+func TestDecodeHTTPCustom(t *testing.T) {
+	alert := decodeFixture(t, "http_custom.json")
+
+	assert.Equal(t, 12350, alert.CheckID)
+	assert.Equal(t, TypeHTTPCustom, alert.CheckType)
+	assert.Equal(t, "https://www.example.com/status.xml", alert.HTTP.FullURL)
+}
+
+func TestDecodeTCP(t *testing.T) {
+	alert := decodeFixture(t, "tcp.json")
+
+	assert.Equal(t, 12346, alert.CheckID)
+	assert.Equal(t, TypeTCP, alert.CheckType)
+	assert.Equal(t, &TCPParams{Hostname: "mail.example.com", Port: 25}, alert.TCP)
+	assert.Nil(t, alert.HTTP)
+	assert.Nil(t, alert.SecondProbe)
+	assert.False(t, alert.Down())
+}
+
+func TestDecodeUDP(t *testing.T) {
+	alert := decodeFixture(t, "udp.json")
+
+	assert.Equal(t, TypeUDP, alert.CheckType)
+	assert.Equal(t, &TCPParams{Hostname: "ntp.example.com", Port: 123, StringToSend: "ping", StringToExpect: "pong"}, alert.TCP)
+}
+
+func TestDecodePing(t *testing.T) {
+	alert := decodeFixture(t, "ping.json")
+
+	assert.Equal(t, 12347, alert.CheckID)
+	assert.Equal(t, TypePing, alert.CheckType)
+	assert.Equal(t, &PingParams{Hostname: "www.example.com", IPv6: true}, alert.Ping)
+	assert.Equal(t, "Frankfurt, Germany", alert.SecondProbe.Location)
+}
+
+func TestDecodeDNS(t *testing.T) {
+	alert := decodeFixture(t, "dns.json")
+
+	assert.Equal(t, 12348, alert.CheckID)
+	assert.Equal(t, TypeDNS, alert.CheckType)
+	assert.Equal(t, &DNSParams{Hostname: "www.example.com", ExpectedIP: "93.184.216.34", Nameserver: "a.iana-servers.net"}, alert.DNS)
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	alert := decodeFixture(t, "transaction.json")
+
+	assert.Equal(t, 12349, alert.CheckID)
+	assert.Equal(t, TypeTransaction, alert.CheckType)
+	assert.Equal(t, StateSuccess, alert.PreviousState)
+	assert.Equal(t, StateFailing, alert.CurrentState)
+	assert.Equal(t, "Timed out waiting for element #checkout", alert.ErrorMessage)
+	assert.Equal(t, "Checkout is broken", alert.CustomMessage)
+	assert.Nil(t, alert.FirstProbe)
+	assert.True(t, alert.Down())
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+		err  string
+	}{
+		{body: `not json`, err: "webhook: invalid payload"},
+		{body: `{"check_id": 1, "check_type": "FTP", "current_state": "DOWN"}`, err: `webhook: unknown check type "FTP"`},
+		{body: `{"check_type": "HTTP", "current_state": "DOWN"}`, err: "webhook: invalid check ID 0"},
+		{body: `{"check_id": 1, "check_type": "HTTP"}`, err: "webhook: missing current state"},
+		{body: `{"check_id": 1, "check_type": "PING", "current_state": "DOWN", "check_params": {"hostname": 1}}`, err: "webhook: invalid check_params for PING check"},
+	}
+	for _, tt := range tests {
+		_, err := Decode(strings.NewReader(tt.body))
+		if assert.Error(t, err, tt.body) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+}